@@ -0,0 +1,495 @@
+package aisdk
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Message is a single chat turn exchanged with an AI provider.
+type Message struct {
+	Role    string `json:"role"` // "system" | "user" | "assistant"
+	Content string `json:"content"`
+}
+
+// ChatOptions configures a single Chat or ChatStream call.
+type ChatOptions struct {
+	Temperature float32
+	MaxTokens   int
+	// Timeout overrides the client's default per-request timeout when non-zero.
+	Timeout time.Duration
+}
+
+// Usage reports token accounting returned by the provider, when available.
+type Usage struct {
+	PromptTokens     int
+	CompletionTokens int
+	TotalTokens      int
+}
+
+// Response is the result of a non-streaming Chat call.
+type Response struct {
+	Content      string
+	FinishReason string
+	Usage        Usage
+}
+
+// Chunk is a single piece of a streamed chat completion. The channel
+// returned by ChatStream is closed after the final Chunk (Done == true or
+// Err != nil) is sent.
+type Chunk struct {
+	Content string
+	Done    bool
+	Err     error
+}
+
+// Logger receives structured diagnostic events from an AIClient. Implementations
+// must be safe for concurrent use.
+type Logger interface {
+	Logf(format string, args ...interface{})
+}
+
+// Metrics receives counters/timings from an AIClient. Implementations must be
+// safe for concurrent use.
+type Metrics interface {
+	ObserveRequest(provider, model string, d time.Duration, err error)
+	IncRetry(provider, model string)
+}
+
+type noopLogger struct{}
+
+func (noopLogger) Logf(string, ...interface{}) {}
+
+type noopMetrics struct{}
+
+func (noopMetrics) ObserveRequest(string, string, time.Duration, error) {}
+func (noopMetrics) IncRetry(string, string)                             {}
+
+// AIClient is the provider-agnostic interface every AI backend implements.
+type AIClient interface {
+	Chat(ctx context.Context, messages []Message, opts ChatOptions) (Response, error)
+	ChatStream(ctx context.Context, messages []Message, opts ChatOptions) (<-chan Chunk, error)
+}
+
+// sharedHTTPClient is reused across every client built by NewAIClient so
+// connections are pooled instead of dialed per request.
+var sharedHTTPClient = &http.Client{
+	Timeout: 60 * time.Second,
+	Transport: &http.Transport{
+		MaxIdleConns:        100,
+		MaxIdleConnsPerHost: 10,
+		IdleConnTimeout:     90 * time.Second,
+	},
+}
+
+// ClientOption customizes a client built by NewAIClient.
+type ClientOption func(*clientOptions)
+
+type clientOptions struct {
+	httpClient *http.Client
+	logger     Logger
+	metrics    Metrics
+	maxRetries int
+	baseDelay  time.Duration
+}
+
+func defaultClientOptions() *clientOptions {
+	return &clientOptions{
+		httpClient: sharedHTTPClient,
+		logger:     noopLogger{},
+		metrics:    noopMetrics{},
+		maxRetries: 3,
+		baseDelay:  250 * time.Millisecond,
+	}
+}
+
+// WithHTTPClient overrides the *http.Client used for requests, e.g. to share
+// a caller-managed connection pool or inject a test transport.
+func WithHTTPClient(c *http.Client) ClientOption {
+	return func(o *clientOptions) { o.httpClient = c }
+}
+
+// WithLogger attaches a Logger for request-level diagnostics.
+func WithLogger(l Logger) ClientOption {
+	return func(o *clientOptions) { o.logger = l }
+}
+
+// WithMetrics attaches a Metrics sink for request counters/timings.
+func WithMetrics(m Metrics) ClientOption {
+	return func(o *clientOptions) { o.metrics = m }
+}
+
+// WithMaxRetries overrides the default retry budget (3) for transient errors.
+func WithMaxRetries(n int) ClientOption {
+	return func(o *clientOptions) { o.maxRetries = n }
+}
+
+// NewAIClient builds the concrete AIClient for cfg.Provider ("openai",
+// "huggingface", or "custom").
+func NewAIClient(cfg *AIProviderConfig, opts ...ClientOption) (AIClient, error) {
+	if cfg == nil {
+		return nil, errors.New("aisdk: nil AIProviderConfig")
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	o := defaultClientOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+	base := baseClient{cfg: cfg, opts: o}
+	switch cfg.Provider {
+	case "openai":
+		return &openaiClient{base}, nil
+	case "huggingface":
+		return &hfClient{base}, nil
+	case "custom":
+		return &customHTTPClient{base}, nil
+	default:
+		return nil, fmt.Errorf("aisdk: unknown AI provider %q", cfg.Provider)
+	}
+}
+
+// baseClient holds the plumbing shared by every concrete AIClient: the
+// pooled http.Client, retry/backoff, and Logger/Metrics hooks.
+type baseClient struct {
+	cfg  *AIProviderConfig
+	opts *clientOptions
+}
+
+// doJSON POSTs body to url with the client's auth header, retrying transient
+// failures (network errors and 429/5xx responses) with exponential backoff
+// and full jitter. It reports every attempt to Metrics and Logger. timeout,
+// when non-zero, overrides the shared http.Client's timeout for this call
+// (ChatOptions.Timeout).
+func (b baseClient) doJSON(ctx context.Context, url string, headers map[string]string, body interface{}, timeout time.Duration) (*http.Response, error) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("aisdk: encoding request: %w", err)
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= b.opts.maxRetries; attempt++ {
+		if attempt > 0 {
+			delay := b.backoff(attempt)
+			b.opts.metrics.IncRetry(b.cfg.Provider, b.cfg.Model)
+			b.opts.logger.Logf("aisdk: retrying %s attempt=%d delay=%s cause=%v", url, attempt, delay, lastErr)
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+
+		if timeout == 0 {
+			timeout = b.opts.httpClient.Timeout
+		}
+		reqCtx := ctx
+		var cancel context.CancelFunc
+		if timeout > 0 {
+			reqCtx, cancel = context.WithTimeout(ctx, timeout)
+		}
+
+		req, err := http.NewRequestWithContext(reqCtx, http.MethodPost, url, bytes.NewReader(payload))
+		if err != nil {
+			if cancel != nil {
+				cancel()
+			}
+			return nil, fmt.Errorf("aisdk: building request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+
+		start := time.Now()
+		resp, err := b.opts.httpClient.Do(req)
+		b.opts.metrics.ObserveRequest(b.cfg.Provider, b.cfg.Model, time.Since(start), err)
+		if cancel != nil {
+			defer cancel()
+		}
+
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+			lastErr = fmt.Errorf("aisdk: %s returned %s", url, resp.Status)
+			resp.Body.Close()
+			continue
+		}
+		if resp.StatusCode >= 400 {
+			defer resp.Body.Close()
+			msg, _ := io.ReadAll(resp.Body)
+			return nil, fmt.Errorf("aisdk: %s returned %s: %s", url, resp.Status, strings.TrimSpace(string(msg)))
+		}
+		return resp, nil
+	}
+	return nil, fmt.Errorf("aisdk: %s failed after %d attempts: %w", url, b.opts.maxRetries+1, lastErr)
+}
+
+// backoff returns an exponential delay with full jitter, capped at 10s.
+func (b baseClient) backoff(attempt int) time.Duration {
+	const maxDelay = 10 * time.Second
+	exp := time.Duration(math.Pow(2, float64(attempt-1))) * b.opts.baseDelay
+	if exp > maxDelay {
+		exp = maxDelay
+	}
+	return time.Duration(rand.Int63n(int64(exp) + 1))
+}
+
+func (o ChatOptions) withDefaults(c *AIProviderConfig) ChatOptions {
+	if o.MaxTokens == 0 {
+		o.MaxTokens = 1024
+	}
+	return o
+}
+
+// openaiClient talks to the OpenAI (or OpenAI-compatible) chat completions API.
+type openaiClient struct{ baseClient }
+
+func (c *openaiClient) Chat(ctx context.Context, messages []Message, opts ChatOptions) (Response, error) {
+	opts = opts.withDefaults(c.cfg)
+	resp, err := c.doJSON(ctx, "https://api.openai.com/v1/chat/completions", map[string]string{
+		"Authorization": "Bearer " + c.cfg.APIKey,
+	}, openaiChatRequest{
+		Model:       c.cfg.Model,
+		Messages:    messages,
+		Temperature: opts.Temperature,
+		MaxTokens:   opts.MaxTokens,
+	}, opts.Timeout)
+	if err != nil {
+		return Response{}, err
+	}
+	defer resp.Body.Close()
+
+	var parsed openaiChatResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return Response{}, fmt.Errorf("aisdk: decoding openai response: %w", err)
+	}
+	if len(parsed.Choices) == 0 {
+		return Response{}, errors.New("aisdk: openai response had no choices")
+	}
+	return Response{
+		Content:      parsed.Choices[0].Message.Content,
+		FinishReason: parsed.Choices[0].FinishReason,
+		Usage: Usage{
+			PromptTokens:     parsed.Usage.PromptTokens,
+			CompletionTokens: parsed.Usage.CompletionTokens,
+			TotalTokens:      parsed.Usage.TotalTokens,
+		},
+	}, nil
+}
+
+func (c *openaiClient) ChatStream(ctx context.Context, messages []Message, opts ChatOptions) (<-chan Chunk, error) {
+	opts = opts.withDefaults(c.cfg)
+	resp, err := c.doJSON(ctx, "https://api.openai.com/v1/chat/completions", map[string]string{
+		"Authorization": "Bearer " + c.cfg.APIKey,
+		"Accept":        "text/event-stream",
+	}, openaiChatRequest{
+		Model:       c.cfg.Model,
+		Messages:    messages,
+		Temperature: opts.Temperature,
+		MaxTokens:   opts.MaxTokens,
+		Stream:      true,
+	}, opts.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	return sseChunks(resp.Body), nil
+}
+
+// sseChunks parses an OpenAI-style `data: {...}` / `data: [DONE]` event
+// stream into Chunks, closing body and the returned channel when the stream
+// ends.
+func sseChunks(body io.ReadCloser) <-chan Chunk {
+	out := make(chan Chunk)
+	go func() {
+		defer close(out)
+		defer body.Close()
+		scanner := bufio.NewScanner(body)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || !strings.HasPrefix(line, "data:") {
+				continue
+			}
+			data := strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+			if data == "[DONE]" {
+				out <- Chunk{Done: true}
+				return
+			}
+			var delta openaiStreamChunk
+			if err := json.Unmarshal([]byte(data), &delta); err != nil {
+				out <- Chunk{Err: fmt.Errorf("aisdk: decoding stream chunk: %w", err)}
+				return
+			}
+			if len(delta.Choices) > 0 && delta.Choices[0].Delta.Content != "" {
+				out <- Chunk{Content: delta.Choices[0].Delta.Content}
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			out <- Chunk{Err: err}
+		}
+	}()
+	return out
+}
+
+type openaiChatRequest struct {
+	Model       string    `json:"model"`
+	Messages    []Message `json:"messages"`
+	Temperature float32   `json:"temperature,omitempty"`
+	MaxTokens   int       `json:"max_tokens,omitempty"`
+	Stream      bool      `json:"stream,omitempty"`
+}
+
+type openaiChatResponse struct {
+	Choices []struct {
+		Message      Message `json:"message"`
+		FinishReason string  `json:"finish_reason"`
+	} `json:"choices"`
+	Usage struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+		TotalTokens      int `json:"total_tokens"`
+	} `json:"usage"`
+}
+
+type openaiStreamChunk struct {
+	Choices []struct {
+		Delta struct {
+			Content string `json:"content"`
+		} `json:"delta"`
+	} `json:"choices"`
+}
+
+// hfClient talks to the Hugging Face Inference API. HF's hosted inference
+// endpoints do not expose an OpenAI-style token stream, so ChatStream wraps
+// a single Chat call and emits it as one terminal Chunk.
+type hfClient struct{ baseClient }
+
+func (c *hfClient) Chat(ctx context.Context, messages []Message, opts ChatOptions) (Response, error) {
+	opts = opts.withDefaults(c.cfg)
+	resp, err := c.doJSON(ctx, "https://api-inference.huggingface.co/models/"+c.cfg.Model, map[string]string{
+		"Authorization": "Bearer " + c.cfg.APIKey,
+	}, hfRequest{
+		Inputs: flattenMessages(messages),
+		Parameters: hfParameters{
+			Temperature: opts.Temperature,
+			MaxNewTok:   opts.MaxTokens,
+		},
+	}, opts.Timeout)
+	if err != nil {
+		return Response{}, err
+	}
+	defer resp.Body.Close()
+
+	var parsed []hfGeneratedText
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return Response{}, fmt.Errorf("aisdk: decoding huggingface response: %w", err)
+	}
+	if len(parsed) == 0 {
+		return Response{}, errors.New("aisdk: huggingface response was empty")
+	}
+	return Response{Content: parsed[0].GeneratedText, FinishReason: "stop"}, nil
+}
+
+func (c *hfClient) ChatStream(ctx context.Context, messages []Message, opts ChatOptions) (<-chan Chunk, error) {
+	out := make(chan Chunk, 1)
+	resp, err := c.Chat(ctx, messages, opts)
+	if err != nil {
+		close(out)
+		return nil, err
+	}
+	out <- Chunk{Content: resp.Content}
+	out <- Chunk{Done: true}
+	close(out)
+	return out, nil
+}
+
+func flattenMessages(messages []Message) string {
+	var sb strings.Builder
+	for _, m := range messages {
+		sb.WriteString(m.Role)
+		sb.WriteString(": ")
+		sb.WriteString(m.Content)
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+type hfRequest struct {
+	Inputs     string       `json:"inputs"`
+	Parameters hfParameters `json:"parameters"`
+}
+
+type hfParameters struct {
+	Temperature float32 `json:"temperature,omitempty"`
+	MaxNewTok   int     `json:"max_new_tokens,omitempty"`
+}
+
+type hfGeneratedText struct {
+	GeneratedText string `json:"generated_text"`
+}
+
+// customHTTPClient talks to a self-hosted or third-party endpoint that
+// speaks the OpenAI chat-completions wire format, rooted at cfg.BaseURL.
+type customHTTPClient struct{ baseClient }
+
+func (c *customHTTPClient) Chat(ctx context.Context, messages []Message, opts ChatOptions) (Response, error) {
+	opts = opts.withDefaults(c.cfg)
+	resp, err := c.doJSON(ctx, c.url(), c.authHeaders(), openaiChatRequest{
+		Model:       c.cfg.Model,
+		Messages:    messages,
+		Temperature: opts.Temperature,
+		MaxTokens:   opts.MaxTokens,
+	}, opts.Timeout)
+	if err != nil {
+		return Response{}, err
+	}
+	defer resp.Body.Close()
+	var parsed openaiChatResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return Response{}, fmt.Errorf("aisdk: decoding custom endpoint response: %w", err)
+	}
+	if len(parsed.Choices) == 0 {
+		return Response{}, errors.New("aisdk: custom endpoint response had no choices")
+	}
+	return Response{Content: parsed.Choices[0].Message.Content, FinishReason: parsed.Choices[0].FinishReason}, nil
+}
+
+func (c *customHTTPClient) ChatStream(ctx context.Context, messages []Message, opts ChatOptions) (<-chan Chunk, error) {
+	opts = opts.withDefaults(c.cfg)
+	headers := c.authHeaders()
+	headers["Accept"] = "text/event-stream"
+	resp, err := c.doJSON(ctx, c.url(), headers, openaiChatRequest{
+		Model:       c.cfg.Model,
+		Messages:    messages,
+		Temperature: opts.Temperature,
+		MaxTokens:   opts.MaxTokens,
+		Stream:      true,
+	}, opts.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	return sseChunks(resp.Body), nil
+}
+
+func (c *customHTTPClient) url() string {
+	return strings.TrimRight(c.cfg.BaseURL, "/") + "/v1/chat/completions"
+}
+
+func (c *customHTTPClient) authHeaders() map[string]string {
+	if c.cfg.APIKey == "" {
+		return map[string]string{}
+	}
+	return map[string]string{"Authorization": "Bearer " + c.cfg.APIKey}
+}
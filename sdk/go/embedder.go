@@ -0,0 +1,107 @@
+package aisdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/lippytm/ai-time-machines/go-service/sdk/go/vector"
+)
+
+// NewEmbedder builds a vector.Embedder for cfg.Provider ("openai" uses
+// text-embedding-3-*, "huggingface" uses a sentence-transformers model).
+func NewEmbedder(cfg *AIProviderConfig, opts ...ClientOption) (vector.Embedder, error) {
+	if cfg == nil {
+		return nil, errors.New("aisdk: nil AIProviderConfig")
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	o := defaultClientOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+	base := baseClient{cfg: cfg, opts: o}
+	switch cfg.Provider {
+	case "openai":
+		return &openAIEmbedder{base}, nil
+	case "huggingface":
+		return &hfEmbedder{base}, nil
+	default:
+		return nil, fmt.Errorf("aisdk: embeddings not supported for provider %q", cfg.Provider)
+	}
+}
+
+// openAIEmbedder calls OpenAI's /v1/embeddings with the text-embedding-3-*
+// family of models.
+type openAIEmbedder struct{ baseClient }
+
+func (e *openAIEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	model := e.cfg.Model
+	if model == "" || model == "gpt-4" {
+		model = "text-embedding-3-small"
+	}
+	resp, err := e.doJSON(ctx, "https://api.openai.com/v1/embeddings", map[string]string{
+		"Authorization": "Bearer " + e.cfg.APIKey,
+	}, openaiEmbeddingRequest{Model: model, Input: texts}, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var parsed openaiEmbeddingResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, fmt.Errorf("aisdk: decoding openai embedding response: %w", err)
+	}
+	out := make([][]float32, len(parsed.Data))
+	for _, d := range parsed.Data {
+		out[d.Index] = d.Embedding
+	}
+	return out, nil
+}
+
+type openaiEmbeddingRequest struct {
+	Model string   `json:"model"`
+	Input []string `json:"input"`
+}
+
+type openaiEmbeddingResponse struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+}
+
+// hfEmbedder calls the Hugging Face Inference API's feature-extraction task
+// against a sentence-transformers model.
+type hfEmbedder struct{ baseClient }
+
+func (e *hfEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	model := e.cfg.Model
+	if model == "" || model == "gpt-4" {
+		model = "sentence-transformers/all-MiniLM-L6-v2"
+	}
+	resp, err := e.doJSON(ctx, "https://api-inference.huggingface.co/pipeline/feature-extraction/"+model, map[string]string{
+		"Authorization": "Bearer " + e.cfg.APIKey,
+	}, hfEmbeddingRequest{Inputs: texts, Options: hfEmbeddingOptions{WaitForModel: true}}, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var vectors [][]float32
+	if err := json.NewDecoder(resp.Body).Decode(&vectors); err != nil {
+		return nil, fmt.Errorf("aisdk: decoding huggingface embedding response: %w", err)
+	}
+	return vectors, nil
+}
+
+type hfEmbeddingRequest struct {
+	Inputs  []string           `json:"inputs"`
+	Options hfEmbeddingOptions `json:"options"`
+}
+
+type hfEmbeddingOptions struct {
+	WaitForModel bool `json:"wait_for_model"`
+}
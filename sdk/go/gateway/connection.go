@@ -0,0 +1,160 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	writeWait = 10 * time.Second
+	pongWait  = 60 * time.Second
+)
+
+// connection serves a single upgraded WebSocket: it reads Requests off the
+// wire, starts/stops subscriptions against the Server's registered Sources,
+// and fans every subscription's events back out as Notifications, applying
+// per-subscription backpressure so one slow stream can't stall the others.
+type connection struct {
+	conn   *websocket.Conn
+	server *Server
+
+	writeMu sync.Mutex // serializes conn.WriteMessage/WriteControl across goroutines
+
+	mu     sync.Mutex
+	cancel map[string]context.CancelFunc // keyed by request ID string
+}
+
+func newConnection(conn *websocket.Conn, server *Server) *connection {
+	return &connection{conn: conn, server: server, cancel: make(map[string]context.CancelFunc)}
+}
+
+func (c *connection) run(ctx context.Context) {
+	ctx, cancelAll := context.WithCancel(ctx)
+	defer cancelAll()
+	defer c.conn.Close()
+
+	c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	c.conn.SetPongHandler(func(string) error {
+		c.conn.SetReadDeadline(time.Now().Add(pongWait))
+		return nil
+	})
+
+	go c.pingLoop(ctx)
+
+	for {
+		_, payload, err := c.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		var req Request
+		if err := json.Unmarshal(payload, &req); err != nil {
+			c.writeError(nil, RPCError{Code: -32700, Message: "parse error"})
+			continue
+		}
+		c.handleRequest(ctx, req)
+	}
+}
+
+func (c *connection) pingLoop(ctx context.Context) {
+	ticker := time.NewTicker(c.server.cfg.PingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.writeMu.Lock()
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			err := c.conn.WriteMessage(websocket.PingMessage, nil)
+			c.writeMu.Unlock()
+			if err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (c *connection) handleRequest(ctx context.Context, req Request) {
+	source, ok := c.server.source(req.Method)
+	if !ok {
+		c.writeError(req.ID, RPCError{Code: -32601, Message: "method not found: " + req.Method})
+		return
+	}
+
+	subCtx, cancel := context.WithCancel(ctx)
+	key := string(req.ID)
+	c.mu.Lock()
+	if prev, exists := c.cancel[key]; exists {
+		prev()
+	}
+	c.cancel[key] = cancel
+	c.mu.Unlock()
+
+	events, err := source.Stream(subCtx, req.Params)
+	if err != nil {
+		cancel()
+		c.writeError(req.ID, RPCError{Code: -32000, Message: err.Error()})
+		return
+	}
+	go c.pump(subCtx, req.ID, req.Method, events)
+}
+
+// pump fans events out to the connection as Notifications, applying
+// backpressure by dropping events once the subscription's buffer is full
+// rather than blocking the Source or stalling every other subscription on
+// this connection.
+func (c *connection) pump(ctx context.Context, id json.RawMessage, method string, events <-chan interface{}) {
+	buf := make(chan interface{}, c.server.cfg.SubscriptionBuffer)
+
+	// Only this goroutine ever sends on buf, so only it may close it.
+	// The read loop below just exits when buf closes; it never closes buf
+	// itself, which would otherwise race with a send here on cancel.
+	go func() {
+		defer close(buf)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case ev, ok := <-events:
+				if !ok {
+					return
+				}
+				select {
+				case buf <- ev:
+				case <-ctx.Done():
+					return
+				default:
+					// Buffer full and the writer can't keep up; drop this
+					// event rather than block the Source.
+				}
+			}
+		}
+	}()
+
+	for ev := range buf {
+		params, err := json.Marshal(ev)
+		if err != nil {
+			continue
+		}
+		c.writeNotification(id, method, params)
+	}
+}
+
+func (c *connection) writeNotification(id json.RawMessage, method string, params json.RawMessage) {
+	c.writeJSON(Notification{JSONRPC: "2.0", ID: id, Method: method, Params: params})
+}
+
+func (c *connection) writeError(id json.RawMessage, rpcErr RPCError) {
+	c.writeJSON(ErrorResponse{JSONRPC: "2.0", ID: id, Error: rpcErr})
+}
+
+func (c *connection) writeJSON(v interface{}) {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+	c.conn.WriteJSON(v)
+}
@@ -0,0 +1,81 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// fastSource streams events as quickly as the channel will take them, so the
+// forwarder goroutine in pump is always racing to send when a subscription
+// is canceled.
+type fastSource struct{}
+
+func (fastSource) Stream(ctx context.Context, params json.RawMessage) (<-chan interface{}, error) {
+	out := make(chan interface{})
+	go func() {
+		defer close(out)
+		for i := 0; ; i++ {
+			select {
+			case <-ctx.Done():
+				return
+			case out <- i:
+			}
+		}
+	}()
+	return out, nil
+}
+
+// TestResubscribeSameIDDoesNotPanic reproduces the scenario a second
+// subscribe on the same request ID triggers: handleRequest cancels the
+// previous subscription's context while its pump goroutine may be mid-send
+// on its internal buffer. Before the fix, pump's own goroutine closed buf
+// from the cancellation path while the forwarder goroutine could still be
+// sending on it, producing "panic: send on closed channel". A panic in the
+// forwarder goroutine crashes the whole test binary, so the absence of a
+// crash (across many iterations, run with -race to also catch the
+// send/close race directly) is the assertion.
+func TestResubscribeSameIDDoesNotPanic(t *testing.T) {
+	server := NewServer(Config{SubscriptionBuffer: 1, PingInterval: time.Hour})
+	server.Register("fast.stream", fastSource{})
+
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(httpServer.URL, "http")
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	if err != nil {
+		t.Fatalf("dialing: %v", err)
+	}
+	defer conn.Close()
+
+	// Drain whatever the server sends so writes don't block on a full
+	// kernel buffer while we hammer resubscribes.
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	const iterations = 4000
+	for i := 0; i < iterations; i++ {
+		req := Request{JSONRPC: "2.0", ID: json.RawMessage(`1`), Method: "fast.stream"}
+		if err := conn.WriteJSON(req); err != nil {
+			t.Fatalf("iteration %d: writing subscribe request: %v", i, err)
+		}
+	}
+
+	conn.Close()
+	wg.Wait()
+}
@@ -0,0 +1,152 @@
+// Package gateway implements a WebSocket streaming server that multiplexes
+// multiple named event sources (chat tokens, log tails, Web3 events) over a
+// single connection as JSON-RPC 2.0 frames. It has no dependency on the
+// parent aisdk package so it can be embedded or consumed standalone.
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// Request is a JSON-RPC 2.0 request frame. A client subscribes to a stream
+// by sending {"jsonrpc":"2.0","id":1,"method":"chat.stream","params":{...}}.
+type Request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// Notification is a JSON-RPC 2.0 notification frame the server sends for
+// every event a subscription produces. ID matches the Request.ID that
+// started the subscription so a client can demultiplex concurrent streams.
+type Notification struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params"`
+}
+
+// ErrorResponse is a JSON-RPC 2.0 error frame.
+type ErrorResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Error   RPCError        `json:"error"`
+}
+
+// RPCError is the "error" member of an ErrorResponse.
+type RPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Source produces a stream of JSON-encodable events for one subscription
+// method (e.g. "chat.stream", "web3.logs", "messaging.events"). The
+// returned channel must be closed by the Source when the stream ends;
+// Source must stop producing once ctx is done.
+type Source interface {
+	Stream(ctx context.Context, params json.RawMessage) (<-chan interface{}, error)
+}
+
+// SourceFunc adapts a function to a Source.
+type SourceFunc func(ctx context.Context, params json.RawMessage) (<-chan interface{}, error)
+
+func (f SourceFunc) Stream(ctx context.Context, params json.RawMessage) (<-chan interface{}, error) {
+	return f(ctx, params)
+}
+
+// Authenticator gates incoming WebSocket connections before the upgrade
+// completes. Returning an error rejects the connection with 401.
+type Authenticator interface {
+	Authenticate(r *http.Request) error
+}
+
+// AuthenticatorFunc adapts a function to an Authenticator.
+type AuthenticatorFunc func(r *http.Request) error
+
+func (f AuthenticatorFunc) Authenticate(r *http.Request) error { return f(r) }
+
+type allowAllAuthenticator struct{}
+
+func (allowAllAuthenticator) Authenticate(*http.Request) error { return nil }
+
+// Config configures a Server.
+type Config struct {
+	// Authenticator gates every incoming connection. Defaults to allowing
+	// everyone when nil.
+	Authenticator Authenticator
+	// SubscriptionBuffer bounds how many pending events a single
+	// subscription may queue before the server applies backpressure by
+	// dropping the oldest unsent event. Defaults to 32.
+	SubscriptionBuffer int
+	// PingInterval controls how often the server pings idle connections to
+	// detect dead peers. Defaults to 30s.
+	PingInterval time.Duration
+}
+
+// Server multiplexes one or more named Sources over WebSocket connections
+// as JSON-RPC 2.0 frames.
+type Server struct {
+	cfg      Config
+	upgrader websocket.Upgrader
+	mu       sync.RWMutex
+	sources  map[string]Source
+}
+
+// NewServer builds a Server from cfg. Register sources with Register before
+// calling ServeHTTP (or SDK.ServeWS, which registers the built-in sources
+// for you).
+func NewServer(cfg Config) *Server {
+	if cfg.Authenticator == nil {
+		cfg.Authenticator = allowAllAuthenticator{}
+	}
+	if cfg.SubscriptionBuffer == 0 {
+		cfg.SubscriptionBuffer = 32
+	}
+	if cfg.PingInterval == 0 {
+		cfg.PingInterval = 30 * time.Second
+	}
+	return &Server{
+		cfg:     cfg,
+		sources: make(map[string]Source),
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  4096,
+			WriteBufferSize: 4096,
+			CheckOrigin:     func(*http.Request) bool { return true },
+		},
+	}
+}
+
+// Register makes method available for subscription, backed by source.
+func (s *Server) Register(method string, source Source) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sources[method] = source
+}
+
+func (s *Server) source(method string) (Source, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	src, ok := s.sources[method]
+	return src, ok
+}
+
+// ServeHTTP upgrades the connection and serves subscriptions until the peer
+// disconnects or r.Context() is done.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := s.cfg.Authenticator.Authenticate(r); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	conn, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	newConnection(conn, s).run(r.Context())
+}
@@ -0,0 +1,107 @@
+package aisdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/lippytm/ai-time-machines/go-service/sdk/go/gateway"
+)
+
+// GatewayOption customizes the gateway.Server built by SDK.ServeWS.
+type GatewayOption func(*gateway.Config)
+
+// WithGatewayAuthenticator sets the Authenticator every incoming WebSocket
+// connection must pass before the upgrade completes.
+func WithGatewayAuthenticator(a gateway.Authenticator) GatewayOption {
+	return func(c *gateway.Config) { c.Authenticator = a }
+}
+
+// WithGatewaySubscriptionBuffer overrides the per-subscription backpressure
+// buffer (see gateway.Config.SubscriptionBuffer).
+func WithGatewaySubscriptionBuffer(n int) GatewayOption {
+	return func(c *gateway.Config) { c.SubscriptionBuffer = n }
+}
+
+// ServeWS registers a WebSocket gateway on mux at path that multiplexes
+// "chat.stream" (backed by s.AIClient), "web3.logs" (backed by
+// s.Web3.Client), and "messaging.events" (left unregistered, since the SDK
+// has no messaging client implementation yet) as JSON-RPC 2.0
+// subscriptions. Additional sources can be registered on the returned
+// *gateway.Server before the mux starts serving.
+func (s *SDK) ServeWS(mux *http.ServeMux, path string, opts ...GatewayOption) (*gateway.Server, error) {
+	cfg := gateway.Config{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	server := gateway.NewServer(cfg)
+	server.Register("chat.stream", gateway.SourceFunc(s.chatStreamSource))
+	server.Register("web3.logs", gateway.SourceFunc(s.web3LogsSource))
+	mux.Handle(path, server)
+	return server, nil
+}
+
+type chatStreamParams struct {
+	Messages []Message   `json:"messages"`
+	Options  ChatOptions `json:"options"`
+}
+
+func (s *SDK) chatStreamSource(ctx context.Context, params json.RawMessage) (<-chan interface{}, error) {
+	var p chatStreamParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, fmt.Errorf("aisdk: decoding chat.stream params: %w", err)
+	}
+	client, err := s.AIClient()
+	if err != nil {
+		return nil, err
+	}
+	chunks, err := client.ChatStream(ctx, p.Messages, p.Options)
+	if err != nil {
+		return nil, err
+	}
+	out := make(chan interface{})
+	go func() {
+		defer close(out)
+		for chunk := range chunks {
+			select {
+			case out <- chunk:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out, nil
+}
+
+type web3LogsParams struct {
+	Address string   `json:"address"`
+	Topics  []string `json:"topics"`
+}
+
+func (s *SDK) web3LogsSource(ctx context.Context, params json.RawMessage) (<-chan interface{}, error) {
+	var p web3LogsParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, fmt.Errorf("aisdk: decoding web3.logs params: %w", err)
+	}
+	client, err := s.Web3.Client()
+	if err != nil {
+		return nil, err
+	}
+	logs, err := client.SubscribeLogs(ctx, p.Address, p.Topics)
+	if err != nil {
+		return nil, err
+	}
+	out := make(chan interface{})
+	go func() {
+		defer close(out)
+		for log := range logs {
+			select {
+			case out <- log:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out, nil
+}
@@ -0,0 +1,27 @@
+package payments
+
+import "errors"
+
+// Config names the on-chain adjudicator contracts a PaymentChannel settles
+// against, plus the ECDSA key it signs vouchers with. Field names follow
+// the nitro-protocol adjudicator split: NAAddress is the NitroAdjudicator
+// (funding/challenge/settlement), VPAAddress is the VirtualPaymentApp
+// (off-chain voucher rules), and CAAddress is the ConsensusApp (fallback
+// outcome when a channel closes without disputed state).
+type Config struct {
+	NAAddress  string
+	VPAAddress string
+	CAAddress  string
+	ChainPK    string
+}
+
+// Validate checks if the configuration is valid.
+func (c Config) Validate() error {
+	if c.ChainPK == "" {
+		return errors.New("payments: ChainPK not configured")
+	}
+	if c.NAAddress == "" {
+		return errors.New("payments: NAAddress not configured")
+	}
+	return nil
+}
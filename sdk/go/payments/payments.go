@@ -0,0 +1,276 @@
+// Package payments implements a lightweight state-channel for metering and
+// settling AI inference calls off-chain, inspired by the ipld-eth-server
+// nitro integration. It has no dependency on the parent aisdk package so it
+// can be embedded or consumed standalone.
+package payments
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Voucher is the off-chain claim a PaymentChannel signs on every Pay call:
+// the cumulative amount owed on the channel as of Nonce. Only the
+// highest-nonce voucher needs to be redeemed on-chain, since it supersedes
+// every earlier one.
+type Voucher struct {
+	ChannelID  string   `json:"channelId"`
+	Cumulative *big.Int `json:"cumulative"`
+	Nonce      uint64   `json:"nonce"`
+}
+
+// SignedVoucher is a Voucher plus the channel payer's ECDSA signature over
+// it, suitable for attaching to a request or submitting to the on-chain
+// adjudicator.
+type SignedVoucher struct {
+	Voucher
+	Signature []byte `json:"signature"`
+}
+
+// hash returns the keccak256 digest signed over a Voucher.
+func (v Voucher) hash() []byte {
+	payload, _ := json.Marshal(v)
+	return crypto.Keccak256(payload)
+}
+
+// Sign signs v with key, returning the resulting SignedVoucher.
+func (v Voucher) Sign(key *ecdsa.PrivateKey) (SignedVoucher, error) {
+	sig, err := crypto.Sign(v.hash(), key)
+	if err != nil {
+		return SignedVoucher{}, fmt.Errorf("payments: signing voucher: %w", err)
+	}
+	return SignedVoucher{Voucher: v, Signature: sig}, nil
+}
+
+// Verify reports whether sv's signature was produced by payer.
+func (sv SignedVoucher) Verify(payer common.Address) bool {
+	pub, err := crypto.SigToPub(sv.Voucher.hash(), sv.Signature)
+	if err != nil {
+		return false
+	}
+	return crypto.PubkeyToAddress(*pub) == payer
+}
+
+// ErrChannelExhausted is returned by Pay once cumulative spend would exceed
+// the channel's deposit.
+var ErrChannelExhausted = errors.New("payments: channel balance exhausted")
+
+// ErrChannelNotOpen is returned by Pay, Redeem, or Close when called before
+// Open or after Close.
+var ErrChannelNotOpen = errors.New("payments: channel is not open")
+
+// PaymentChannel is a single state channel between this party (the payer)
+// and a counterparty (typically the AI provider/operator), metering spend
+// with ECDSA-signed vouchers instead of an on-chain transaction per call.
+// Settlement of the final voucher against the on-chain adjudicator (see
+// Config) happens in Close.
+type PaymentChannel struct {
+	cfg Config
+	key *ecdsa.PrivateKey
+
+	mu           sync.Mutex
+	id           string
+	counterparty string
+	deposit      *big.Int
+	cumulative   *big.Int
+	nonce        uint64
+	open         bool
+	latest       SignedVoucher
+}
+
+// New constructs a PaymentChannel that will sign vouchers with cfg.ChainPK.
+// The channel is not usable until Open is called.
+func New(cfg Config) (*PaymentChannel, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	key, err := crypto.HexToECDSA(cfg.ChainPK)
+	if err != nil {
+		return nil, fmt.Errorf("payments: parsing Config.ChainPK: %w", err)
+	}
+	return &PaymentChannel{cfg: cfg, key: key}, nil
+}
+
+// Open starts a channel to counterparty funded with deposit. In a full
+// implementation this submits a funding transaction to the NitroAdjudicator
+// (cfg.NAAddress) and waits for it to be mined; this implementation assumes
+// funding happens out of band and only establishes the off-chain bookkeeping
+// needed to meter spend against deposit.
+func (pc *PaymentChannel) Open(counterparty string, deposit *big.Int) error {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	if pc.open {
+		return errors.New("payments: channel already open")
+	}
+	pc.id = channelID(crypto.PubkeyToAddress(pc.key.PublicKey), counterparty, pc.cfg.NAAddress)
+	pc.counterparty = counterparty
+	pc.deposit = new(big.Int).Set(deposit)
+	pc.cumulative = big.NewInt(0)
+	pc.nonce = 0
+	pc.open = true
+	return nil
+}
+
+// ID returns the channel's identifier once open, so the payer can hand it
+// (together with its own address) to the counterparty for building a
+// Redeemer on the matching channel. ID is empty before Open.
+func (pc *PaymentChannel) ID() string {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	return pc.id
+}
+
+// channelID deterministically derives a channel identifier from the payer's
+// address, the counterparty, and the adjudicator address, so both sides
+// compute the same ID without an on-chain round-trip. It is keyed off the
+// payer's address rather than its private key so the counterparty, who
+// never holds that key, can derive it too (see NewRedeemer).
+func channelID(payer common.Address, counterparty, adjudicator string) string {
+	digest := crypto.Keccak256(payer.Bytes(), []byte(counterparty), []byte(adjudicator))
+	return fmt.Sprintf("0x%x", digest)
+}
+
+// Pay advances the channel's cumulative amount by amount and returns a newly
+// signed voucher for it. voucherID is accepted for caller-side correlation
+// (e.g. request tracing) but is not part of the signed payload, since only
+// the cumulative amount and nonce are needed to supersede prior vouchers.
+// Pay returns ErrChannelExhausted once cumulative would exceed the
+// channel's deposit.
+func (pc *PaymentChannel) Pay(amount *big.Int, voucherID string) (SignedVoucher, error) {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	if !pc.open {
+		return SignedVoucher{}, ErrChannelNotOpen
+	}
+	next := new(big.Int).Add(pc.cumulative, amount)
+	if next.Cmp(pc.deposit) > 0 {
+		return SignedVoucher{}, ErrChannelExhausted
+	}
+	pc.nonce++
+	voucher := Voucher{ChannelID: pc.id, Cumulative: next, Nonce: pc.nonce}
+	signed, err := voucher.Sign(pc.key)
+	if err != nil {
+		return SignedVoucher{}, err
+	}
+	pc.cumulative = next
+	pc.latest = signed
+	return signed, nil
+}
+
+// Remaining returns the deposit minus the cumulative amount already paid.
+func (pc *PaymentChannel) Remaining() *big.Int {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	if !pc.open {
+		return big.NewInt(0)
+	}
+	return new(big.Int).Sub(pc.deposit, pc.cumulative)
+}
+
+// Close settles the channel. In a full implementation this submits the
+// latest voucher this payer signed to the NitroAdjudicator (cfg.NAAddress)
+// to start the challenge period; this implementation marks the channel
+// closed locally and returns that voucher for the caller to submit, e.g. to
+// unilaterally withdraw after the counterparty goes unresponsive. The
+// counterparty settles from its own accumulated view instead, via
+// Redeemer.Close.
+func (pc *PaymentChannel) Close() (SignedVoucher, error) {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	if !pc.open {
+		return SignedVoucher{}, ErrChannelNotOpen
+	}
+	pc.open = false
+	return pc.latest, nil
+}
+
+// Redeemer is the receive-side counterpart to PaymentChannel: it validates
+// and accumulates vouchers a payer sends, without ever holding the payer's
+// private key. This is the role the AI provider/operator plays in a
+// metered channel (see the payer side in NewMeteredAIClient), so unlike
+// PaymentChannel it is constructed from the payer's public address rather
+// than a Config with a ChainPK.
+type Redeemer struct {
+	payer        common.Address
+	counterparty string
+	naAddress    string
+
+	mu     sync.Mutex
+	id     string
+	open   bool
+	latest SignedVoucher
+}
+
+// NewRedeemer builds a Redeemer for vouchers payer signs on a channel to
+// counterparty (this side's own identifier, matching whatever string the
+// payer passed to PaymentChannel.Open) settling against naAddress. The
+// channel ID is derived immediately so it matches PaymentChannel.ID once
+// the payer opens with the same counterparty and adjudicator.
+func NewRedeemer(payer common.Address, counterparty, naAddress string) *Redeemer {
+	return &Redeemer{
+		payer:        payer,
+		counterparty: counterparty,
+		naAddress:    naAddress,
+		id:           channelID(payer, counterparty, naAddress),
+	}
+}
+
+// ID returns the channel identifier this Redeemer expects vouchers to carry.
+func (r *Redeemer) ID() string {
+	return r.id
+}
+
+// Open marks the channel ready to accept vouchers. As with
+// PaymentChannel.Open, funding is assumed to happen out of band; this only
+// starts the receive-side bookkeeping.
+func (r *Redeemer) Open() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.open = true
+}
+
+// Redeem records v as the channel's latest claimable voucher, rejecting it
+// unless it was signed by this channel's payer, targets this channel, and
+// carries a higher nonce than whatever is already latest. The counterparty
+// calls Redeem as vouchers arrive so that whichever is highest nonce at
+// Close time is the one submitted to the adjudicator.
+func (r *Redeemer) Redeem(v SignedVoucher) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.open {
+		return ErrChannelNotOpen
+	}
+	if v.ChannelID != r.id {
+		return fmt.Errorf("payments: voucher channel %q does not match this channel %q", v.ChannelID, r.id)
+	}
+	if !v.Verify(r.payer) {
+		return errors.New("payments: voucher signature does not match channel payer")
+	}
+	if v.Nonce < r.latest.Nonce {
+		return fmt.Errorf("payments: stale voucher nonce %d, latest is %d", v.Nonce, r.latest.Nonce)
+	}
+	r.latest = v
+	return nil
+}
+
+// Close settles the channel from the counterparty's side. In a full
+// implementation this submits the latest redeemed voucher to the
+// NitroAdjudicator (naAddress) to start the challenge period; this
+// implementation marks the channel closed locally and returns that voucher
+// for the caller to submit.
+func (r *Redeemer) Close() (SignedVoucher, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.open {
+		return SignedVoucher{}, ErrChannelNotOpen
+	}
+	r.open = false
+	return r.latest, nil
+}
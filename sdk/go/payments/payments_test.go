@@ -0,0 +1,134 @@
+package payments
+
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func hexPrivateKey(key *ecdsa.PrivateKey) string {
+	return hex.EncodeToString(crypto.FromECDSA(key))
+}
+
+func TestVoucherSignAndVerify(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+	v := Voucher{ChannelID: "0xabc", Cumulative: big.NewInt(100), Nonce: 1}
+
+	signed, err := v.Sign(key)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	payer := crypto.PubkeyToAddress(key.PublicKey)
+	if !signed.Verify(payer) {
+		t.Fatal("Verify returned false for an untampered voucher signed by payer")
+	}
+
+	other, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+	if signed.Verify(crypto.PubkeyToAddress(other.PublicKey)) {
+		t.Fatal("Verify returned true for the wrong payer address")
+	}
+
+	tampered := signed
+	tampered.Cumulative = big.NewInt(999)
+	if tampered.Verify(payer) {
+		t.Fatal("Verify returned true for a voucher whose amount was tampered with after signing")
+	}
+}
+
+func TestPaymentChannelPayAndExhaustion(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+	cfg := Config{NAAddress: "0xNA", VPAAddress: "0xVPA", CAAddress: "0xCA", ChainPK: hexPrivateKey(key)}
+	pc, err := New(cfg)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if err := pc.Open("0xCounterparty", big.NewInt(100)); err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+
+	signed, err := pc.Pay(big.NewInt(60), "req-1")
+	if err != nil {
+		t.Fatalf("Pay: %v", err)
+	}
+	if signed.Nonce != 1 || signed.Cumulative.Cmp(big.NewInt(60)) != 0 {
+		t.Fatalf("unexpected voucher %+v", signed.Voucher)
+	}
+	if got := pc.Remaining(); got.Cmp(big.NewInt(40)) != 0 {
+		t.Fatalf("Remaining = %s, want 40", got)
+	}
+
+	if _, err := pc.Pay(big.NewInt(50), "req-2"); err != ErrChannelExhausted {
+		t.Fatalf("Pay over remaining balance = %v, want ErrChannelExhausted", err)
+	}
+}
+
+func TestRedeemerRejectsStaleForgedAndCrossChannelVouchers(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+	cfg := Config{NAAddress: "0xNA", VPAAddress: "0xVPA", CAAddress: "0xCA", ChainPK: hexPrivateKey(key)}
+	pc, err := New(cfg)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if err := pc.Open("0xCounterparty", big.NewInt(100)); err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+
+	payer := crypto.PubkeyToAddress(key.PublicKey)
+	redeemer := NewRedeemer(payer, "0xCounterparty", cfg.NAAddress)
+	if redeemer.ID() != pc.ID() {
+		t.Fatalf("Redeemer.ID() = %q, want %q to match the payer's channel", redeemer.ID(), pc.ID())
+	}
+	redeemer.Open()
+
+	first, err := pc.Pay(big.NewInt(10), "req-1")
+	if err != nil {
+		t.Fatalf("Pay: %v", err)
+	}
+	second, err := pc.Pay(big.NewInt(10), "req-2")
+	if err != nil {
+		t.Fatalf("Pay: %v", err)
+	}
+	if err := redeemer.Redeem(second); err != nil {
+		t.Fatalf("Redeem(second): %v", err)
+	}
+	if err := redeemer.Redeem(first); err == nil {
+		t.Fatal("Redeem accepted a voucher with a lower nonce than the latest redeemed one")
+	}
+
+	forgerKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+	forged := Voucher{ChannelID: second.ChannelID, Cumulative: big.NewInt(1000), Nonce: 3}
+	forgedSigned, err := forged.Sign(forgerKey)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	if err := redeemer.Redeem(forgedSigned); err == nil {
+		t.Fatal("Redeem accepted a voucher signed by a key other than the channel's payer")
+	}
+
+	otherChannel := Voucher{ChannelID: "0xSomeOtherChannel", Cumulative: big.NewInt(1000), Nonce: 3}
+	otherChannelSigned, err := otherChannel.Sign(key)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	if err := redeemer.Redeem(otherChannelSigned); err == nil {
+		t.Fatal("Redeem accepted a validly-signed voucher meant for a different channel")
+	}
+}
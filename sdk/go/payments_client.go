@@ -0,0 +1,69 @@
+package aisdk
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+
+	"github.com/lippytm/ai-time-machines/go-service/sdk/go/payments"
+)
+
+// PaymentChannel builds the payments.PaymentChannel for s.Payments. The
+// returned channel is not yet open; call Open before using it to pay.
+func (s *SDK) PaymentChannel() (*payments.PaymentChannel, error) {
+	if err := s.Payments.Validate(); err != nil {
+		return nil, err
+	}
+	return payments.New(payments.Config{
+		NAAddress:  s.Payments.NAAddress,
+		VPAAddress: s.Payments.VPAAddress,
+		CAAddress:  s.Payments.CAAddress,
+		ChainPK:    s.Payments.ChainPK,
+	})
+}
+
+// voucherHeader is the HTTP header a metered AIClient attaches to every
+// outgoing request: a base64-encoded JSON payments.SignedVoucher the
+// provider/operator can redeem against the channel.
+const voucherHeader = "X-Payment-Voucher"
+
+// voucherTransport wraps an http.RoundTripper, paying pricePerCall on the
+// channel before every request and attaching the resulting voucher as a
+// header. Requests are refused (without reaching the network) once the
+// channel's balance is exhausted.
+type voucherTransport struct {
+	base         http.RoundTripper
+	channel      *payments.PaymentChannel
+	pricePerCall *big.Int
+}
+
+func (t *voucherTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	voucher, err := t.channel.Pay(t.pricePerCall, req.URL.String())
+	if err != nil {
+		return nil, fmt.Errorf("aisdk: payment rejected: %w", err)
+	}
+	encoded, err := json.Marshal(voucher)
+	if err != nil {
+		return nil, fmt.Errorf("aisdk: encoding payment voucher: %w", err)
+	}
+	req.Header.Set(voucherHeader, base64.StdEncoding.EncodeToString(encoded))
+	return t.base.RoundTrip(req)
+}
+
+// NewMeteredAIClient builds an AIClient for cfg that pays pricePerCall on
+// channel before every request, attaching the signed voucher as a header
+// (see voucherHeader) and rejecting calls once the channel's balance is
+// exhausted instead of reaching the provider. channel must already be open
+// (see SDK.PaymentChannel and payments.PaymentChannel.Open).
+func NewMeteredAIClient(cfg *AIProviderConfig, channel *payments.PaymentChannel, pricePerCall *big.Int, opts ...ClientOption) (AIClient, error) {
+	base := *sharedHTTPClient
+	base.Transport = &voucherTransport{
+		base:         sharedHTTPClient.Transport,
+		channel:      channel,
+		pricePerCall: pricePerCall,
+	}
+	opts = append([]ClientOption{WithHTTPClient(&base)}, opts...)
+	return NewAIClient(cfg, opts...)
+}
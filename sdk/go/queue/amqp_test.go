@@ -0,0 +1,29 @@
+package queue
+
+import (
+	"testing"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+func TestAttemptsFromHeaders(t *testing.T) {
+	cases := []struct {
+		name string
+		hdrs amqp.Table
+		want int
+	}{
+		{"nil headers is first delivery", nil, 0},
+		{"no attempts key is first delivery", amqp.Table{}, 0},
+		{"int64 as requeue writes it", amqp.Table{attemptsHeader: int64(2)}, 2},
+		{"int32", amqp.Table{attemptsHeader: int32(3)}, 3},
+		{"int", amqp.Table{attemptsHeader: 4}, 4},
+		{"unexpected type defaults to 0", amqp.Table{attemptsHeader: "oops"}, 0},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := attemptsFromHeaders(c.hdrs); got != c.want {
+				t.Fatalf("attemptsFromHeaders(%v) = %d, want %d", c.hdrs, got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,214 @@
+// Package queue provides a provider-agnostic async job queue: Publisher and
+// Subscriber interfaces with drivers for SQL (Postgres/MySQL), Redis
+// Streams, and AMQP, plus a JobRouter that dispatches messages to handler
+// funcs with dead-letter redelivery and poison-message tracking.
+package queue
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// newMessageID generates a random hex ID for a newly published message.
+func newMessageID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// Message is a single unit of work moving through the queue.
+type Message struct {
+	ID       string
+	Topic    string
+	Payload  []byte
+	Attempts int
+	// ack/nack are set by the driver that delivered this Message so
+	// Ack/Nack can be called without threading driver internals through
+	// the JobRouter.
+	ack  func(ctx context.Context) error
+	nack func(ctx context.Context) error
+}
+
+// Ack acknowledges successful processing of the message.
+func (m Message) Ack(ctx context.Context) error {
+	if m.ack == nil {
+		return nil
+	}
+	return m.ack(ctx)
+}
+
+// Nack signals failed processing; the driver redelivers or dead-letters the
+// message depending on its own retry policy.
+func (m Message) Nack(ctx context.Context) error {
+	if m.nack == nil {
+		return nil
+	}
+	return m.nack(ctx)
+}
+
+// WithAckFuncs returns a copy of m with its Ack/Nack callbacks set. Drivers
+// use this to hand back a Message that carries its own delivery handle.
+func (m Message) WithAckFuncs(ack, nack func(ctx context.Context) error) Message {
+	m.ack, m.nack = ack, nack
+	return m
+}
+
+// Publisher sends payloads to a topic.
+type Publisher interface {
+	Publish(ctx context.Context, topic string, payload []byte) error
+	Close() error
+}
+
+// Subscriber delivers messages published to a topic.
+type Subscriber interface {
+	// Subscribe returns a channel of Messages for topic. The channel is
+	// closed when ctx is canceled or the subscription is closed.
+	Subscribe(ctx context.Context, topic string) (<-chan Message, error)
+	Close() error
+}
+
+// HandlerFunc processes a single Message. A returned error causes the
+// JobRouter to Nack the message (triggering retry/DLQ policy); a nil error
+// Acks it.
+type HandlerFunc func(ctx context.Context, msg Message) error
+
+// RouterConfig configures dead-letter redelivery and poison-message
+// handling for a JobRouter.
+type RouterConfig struct {
+	// DeadLetterTopic receives messages that have failed MaxRetries times.
+	// If empty, poison messages are dropped (after being logged).
+	DeadLetterTopic string
+	// MaxRetries is how many times a message may fail before being
+	// dead-lettered. Zero means unlimited retries.
+	MaxRetries int
+	// HandlerName tags log lines, useful when multiple routers share a
+	// process.
+	HandlerName string
+	Logger      *log.Logger
+}
+
+// JobRouter binds HandlerFuncs to topic names and drives a Subscriber,
+// applying dead-letter redelivery after MaxRetries consecutive failures.
+//
+// Poison tracking is keyed off Message.Attempts, which every driver
+// persists durably (the SQL driver's attempts column, the Redis Streams
+// driver's XPENDING delivery count, the AMQP driver's attempts header) —
+// not an in-process counter. That durability is what makes MaxRetries
+// survive a router restart: a message that already failed 4 of
+// MaxRetries=5 times resumes at 4, not 0.
+type JobRouter struct {
+	cfg      RouterConfig
+	sub      Subscriber
+	pub      Publisher // used to push to DeadLetterTopic; may be nil
+	handlers map[string]HandlerFunc
+}
+
+// NewJobRouter builds a JobRouter that reads from sub and, when messages are
+// dead-lettered, republishes them via pub (which may be nil if cfg has no
+// DeadLetterTopic).
+func NewJobRouter(sub Subscriber, pub Publisher, cfg RouterConfig) *JobRouter {
+	if cfg.Logger == nil {
+		cfg.Logger = log.Default()
+	}
+	return &JobRouter{
+		cfg:      cfg,
+		sub:      sub,
+		pub:      pub,
+		handlers: make(map[string]HandlerFunc),
+	}
+}
+
+// Handle registers handler for topic.
+func (r *JobRouter) Handle(topic string, handler HandlerFunc) {
+	r.handlers[topic] = handler
+}
+
+// Run subscribes to every registered topic and dispatches messages to their
+// handlers until ctx is canceled. It blocks until all in-flight handlers
+// have returned, so callers get a clean, graceful shutdown on cancellation.
+func (r *JobRouter) Run(ctx context.Context) error {
+	var wg sync.WaitGroup
+	errCh := make(chan error, len(r.handlers))
+
+	// Drain errCh concurrently with dispatch, not just after wg.Wait(): a
+	// topic goroutine can produce far more than len(r.handlers) errors over
+	// Run's lifetime (e.g. a flaky driver failing Ack repeatedly), and a
+	// topic goroutine blocked sending to a full errCh stalls dispatch for
+	// that topic indefinitely, even while ctx is still active.
+	var mu sync.Mutex
+	var firstErr error
+	drained := make(chan struct{})
+	go func() {
+		defer close(drained)
+		for err := range errCh {
+			mu.Lock()
+			if firstErr == nil {
+				firstErr = err
+			}
+			mu.Unlock()
+		}
+	}()
+
+	for topic, handler := range r.handlers {
+		msgs, err := r.sub.Subscribe(ctx, topic)
+		if err != nil {
+			return fmt.Errorf("queue: subscribing to %q: %w", topic, err)
+		}
+		wg.Add(1)
+		go func(topic string, handler HandlerFunc, msgs <-chan Message) {
+			defer wg.Done()
+			for msg := range msgs {
+				if err := r.dispatch(ctx, msg, handler); err != nil {
+					errCh <- err
+				}
+			}
+		}(topic, handler, msgs)
+	}
+
+	<-ctx.Done()
+	wg.Wait()
+	close(errCh)
+	<-drained
+
+	mu.Lock()
+	defer mu.Unlock()
+	if firstErr != nil {
+		return firstErr
+	}
+	return ctx.Err()
+}
+
+// dispatch runs handler for msg, Acking on success and applying the
+// dead-letter policy on failure.
+func (r *JobRouter) dispatch(ctx context.Context, msg Message, handler HandlerFunc) error {
+	if err := handler(ctx, msg); err != nil {
+		r.cfg.Logger.Printf("queue[%s]: handler for %q failed (msg=%s attempts=%d): %v",
+			r.cfg.HandlerName, msg.Topic, msg.ID, msg.Attempts+1, err)
+		return r.handleFailure(ctx, msg)
+	}
+	return msg.Ack(ctx)
+}
+
+func (r *JobRouter) handleFailure(ctx context.Context, msg Message) error {
+	attempts := msg.Attempts + 1 // this failure
+	if r.cfg.MaxRetries > 0 && attempts >= r.cfg.MaxRetries {
+		r.cfg.Logger.Printf("queue[%s]: msg=%s exceeded MaxRetries=%d, dead-lettering",
+			r.cfg.HandlerName, msg.ID, r.cfg.MaxRetries)
+		if r.pub != nil && r.cfg.DeadLetterTopic != "" {
+			if err := r.pub.Publish(ctx, r.cfg.DeadLetterTopic, msg.Payload); err != nil {
+				return fmt.Errorf("queue: publishing %s to DLQ: %w", msg.ID, err)
+			}
+		}
+		return msg.Ack(ctx) // remove from the source topic now that it's been handled terminally
+	}
+	return msg.Nack(ctx)
+}
+
+// pollInterval is the default interval polling-based drivers (SQL) use
+// between empty polls.
+const pollInterval = 500 * time.Millisecond
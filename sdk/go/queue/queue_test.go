@@ -0,0 +1,164 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// chanSubscriber hands back a fixed channel of Messages regardless of topic,
+// for tests that want to feed JobRouter.Run a prepared stream directly.
+type chanSubscriber struct {
+	ch chan Message
+}
+
+func (s *chanSubscriber) Subscribe(ctx context.Context, topic string) (<-chan Message, error) {
+	return s.ch, nil
+}
+
+func (s *chanSubscriber) Close() error { return nil }
+
+// fakePublisher records every Publish call so tests can assert DLQ fanout.
+type fakePublisher struct {
+	published []struct {
+		topic   string
+		payload []byte
+	}
+}
+
+func (p *fakePublisher) Publish(ctx context.Context, topic string, payload []byte) error {
+	p.published = append(p.published, struct {
+		topic   string
+		payload []byte
+	}{topic, payload})
+	return nil
+}
+
+func (p *fakePublisher) Close() error { return nil }
+
+func TestJobRouterHandleFailureUsesPersistedAttempts(t *testing.T) {
+	pub := &fakePublisher{}
+	r := NewJobRouter(nil, pub, RouterConfig{
+		DeadLetterTopic: "dead",
+		MaxRetries:      3,
+		HandlerName:     "test",
+	})
+
+	var acked, nacked int
+	msg := Message{ID: "m1", Topic: "work", Payload: []byte("p")}
+	msg = msg.WithAckFuncs(
+		func(ctx context.Context) error { acked++; return nil },
+		func(ctx context.Context) error { nacked++; return nil },
+	)
+
+	// Simulate a message that, per the durable attempts counter a driver
+	// persists, has already failed twice (e.g. the router restarted
+	// between deliveries). A single further failure must be enough to
+	// dead-letter it: retry counts keyed off Message.Attempts must not
+	// reset to zero just because this process is new.
+	msg.Attempts = 2
+	if err := r.dispatch(context.Background(), msg, func(ctx context.Context, m Message) error {
+		return errors.New("handler failed")
+	}); err != nil {
+		t.Fatalf("dispatch: %v", err)
+	}
+
+	if nacked != 0 {
+		t.Fatalf("nacked = %d, want 0 (message should have been dead-lettered, not requeued)", nacked)
+	}
+	if acked != 1 {
+		t.Fatalf("acked = %d, want 1 (dead-lettered messages are acked off the source topic)", acked)
+	}
+	if len(pub.published) != 1 || pub.published[0].topic != "dead" {
+		t.Fatalf("published = %+v, want one publish to the dead-letter topic", pub.published)
+	}
+}
+
+func TestJobRouterHandleFailureRetriesBelowMaxRetries(t *testing.T) {
+	pub := &fakePublisher{}
+	r := NewJobRouter(nil, pub, RouterConfig{
+		DeadLetterTopic: "dead",
+		MaxRetries:      3,
+		HandlerName:     "test",
+	})
+
+	var acked, nacked int
+	msg := Message{ID: "m1", Topic: "work", Payload: []byte("p"), Attempts: 0}
+	msg = msg.WithAckFuncs(
+		func(ctx context.Context) error { acked++; return nil },
+		func(ctx context.Context) error { nacked++; return nil },
+	)
+
+	if err := r.dispatch(context.Background(), msg, func(ctx context.Context, m Message) error {
+		return errors.New("handler failed")
+	}); err != nil {
+		t.Fatalf("dispatch: %v", err)
+	}
+
+	if nacked != 1 {
+		t.Fatalf("nacked = %d, want 1 (first failure of 3 should requeue, not dead-letter)", nacked)
+	}
+	if acked != 0 || len(pub.published) != 0 {
+		t.Fatalf("acked=%d published=%v, want no DLQ activity below MaxRetries", acked, pub.published)
+	}
+}
+
+func TestJobRouterDispatchSuccessAcks(t *testing.T) {
+	r := NewJobRouter(nil, nil, RouterConfig{MaxRetries: 3, HandlerName: "test"})
+
+	var acked int
+	msg := Message{ID: "m1", Topic: "work"}
+	msg = msg.WithAckFuncs(
+		func(ctx context.Context) error { acked++; return nil },
+		func(ctx context.Context) error { t.Fatal("Nack should not be called on success"); return nil },
+	)
+
+	if err := r.dispatch(context.Background(), msg, func(ctx context.Context, m Message) error {
+		return nil
+	}); err != nil {
+		t.Fatalf("dispatch: %v", err)
+	}
+	if acked != 1 {
+		t.Fatalf("acked = %d, want 1", acked)
+	}
+}
+
+// TestJobRouterRunDoesNotBlockOnErrCh reproduces the scenario a flaky
+// driver causes: every dispatch on a topic fails (here, Nack itself
+// returns an error), producing far more errCh sends than JobRouter.Run's
+// buffer (sized len(handlers)) can hold. Before the fix, nothing read
+// errCh until after wg.Wait(), so the topic goroutine blocked forever on
+// the first send past capacity and wg.Wait() never returned. The bounded
+// context here catches that as a hang instead of a pass.
+func TestJobRouterRunDoesNotBlockOnErrCh(t *testing.T) {
+	nackErr := errors.New("nack failed")
+	msgs := make(chan Message, 50)
+	for i := 0; i < 50; i++ {
+		msg := Message{ID: fmt.Sprintf("m%d", i), Topic: "work"}
+		msgs <- msg.WithAckFuncs(
+			func(ctx context.Context) error { return nil },
+			func(ctx context.Context) error { return nackErr },
+		)
+	}
+	close(msgs)
+
+	r := NewJobRouter(&chanSubscriber{ch: msgs}, nil, RouterConfig{HandlerName: "test"})
+	r.Handle("work", func(ctx context.Context, m Message) error { return errors.New("handler failed") })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() { done <- r.Run(ctx) }()
+
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Fatal("Run returned a nil error, want the Nack failure to surface")
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("Run did not return: errCh appears to have blocked dispatch")
+	}
+}
@@ -0,0 +1,228 @@
+package queue
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// defaultMinIdle is how long an entry must sit unacknowledged in the
+// Pending Entries List before the reclaim loop will XAUTOCLAIM it to this
+// consumer. It must comfortably exceed a normal handler's run time: it is
+// not an Ack/Nack deadline, just a crash-recovery backstop, mirroring
+// sqlBroker's defaultLease.
+const defaultMinIdle = 30 * time.Second
+
+// defaultClaimInterval is how often the reclaim loop polls for stale
+// pending entries.
+const defaultClaimInterval = 15 * time.Second
+
+// NewConsumerName derives a consumer name for NewRedisStreamsBroker that is
+// unique to this process, by suffixing base (typically the handler name)
+// with a random ID. Every broker instance in a deployment must use a
+// distinct consumer name within its group, or they collide on Redis
+// Streams consumer identity and break PEL-based crash recovery.
+func NewConsumerName(base string) string {
+	return base + "-" + newMessageID()
+}
+
+// redisStreamsBroker is a Publisher+Subscriber over Redis Streams, using a
+// single consumer group per topic so Ack/Nack map onto XACK / redelivery.
+type redisStreamsBroker struct {
+	client        *redis.Client
+	group         string
+	consumer      string
+	minIdle       time.Duration
+	claimInterval time.Duration
+}
+
+// RedisStreamsBrokerOption customizes a broker built by NewRedisStreamsBroker.
+type RedisStreamsBrokerOption func(*redisStreamsBroker)
+
+// WithMinIdle overrides how long an entry must be pending before the
+// reclaim loop will claim it (defaultMinIdle).
+func WithMinIdle(d time.Duration) RedisStreamsBrokerOption {
+	return func(b *redisStreamsBroker) { b.minIdle = d }
+}
+
+// WithClaimInterval overrides how often the reclaim loop polls for stale
+// pending entries (defaultClaimInterval).
+func WithClaimInterval(d time.Duration) RedisStreamsBrokerOption {
+	return func(b *redisStreamsBroker) { b.claimInterval = d }
+}
+
+// NewRedisStreamsBroker builds a Publisher+Subscriber backed by Redis
+// Streams. group and consumer identify this broker's consumer group
+// membership (see Redis XREADGROUP); every broker instance in a given
+// deployment should share group but use a unique consumer name.
+func NewRedisStreamsBroker(addr, group, consumer string, opts ...RedisStreamsBrokerOption) *redisStreamsBroker {
+	b := &redisStreamsBroker{
+		client:        redis.NewClient(&redis.Options{Addr: addr}),
+		group:         group,
+		consumer:      consumer,
+		minIdle:       defaultMinIdle,
+		claimInterval: defaultClaimInterval,
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+func (b *redisStreamsBroker) Publish(ctx context.Context, topic string, payload []byte) error {
+	err := b.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: topic,
+		Values: map[string]interface{}{"payload": payload},
+	}).Err()
+	if err != nil {
+		return fmt.Errorf("queue: publishing to stream %q: %w", topic, err)
+	}
+	return nil
+}
+
+// Subscribe ensures the consumer group exists on topic and starts reading
+// new entries, converting each to a Message whose Ack/Nack call XACK or
+// leave the entry pending for redelivery, respectively.
+func (b *redisStreamsBroker) Subscribe(ctx context.Context, topic string) (<-chan Message, error) {
+	err := b.client.XGroupCreateMkStream(ctx, topic, b.group, "$").Err()
+	if err != nil && !isBusyGroupErr(err) {
+		return nil, fmt.Errorf("queue: creating consumer group on %q: %w", topic, err)
+	}
+
+	out := make(chan Message)
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+			}
+			streams, err := b.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+				Group:    b.group,
+				Consumer: b.consumer,
+				Streams:  []string{topic, ">"},
+				Count:    10,
+				Block:    0,
+			}).Result()
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				continue
+			}
+			for _, stream := range streams {
+				for _, entry := range stream.Messages {
+					msg := b.toMessage(ctx, topic, entry)
+					select {
+					case out <- msg:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}
+	}()
+	go b.reclaimLoop(ctx, topic, out, &wg)
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out, nil
+}
+
+// reclaimLoop periodically XAUTOCLAIMs entries that have sat idle in the
+// Pending Entries List for longer than b.minIdle (e.g. left there by Nack,
+// or orphaned by a consumer that crashed mid-handler) and redelivers them
+// on out, same as a fresh XREADGROUP delivery. Without this, a Nacked
+// entry would sit in the PEL forever: nothing else re-reads it.
+func (b *redisStreamsBroker) reclaimLoop(ctx context.Context, topic string, out chan<- Message, wg *sync.WaitGroup) {
+	defer wg.Done()
+	ticker := time.NewTicker(b.claimInterval)
+	defer ticker.Stop()
+	cursor := "0-0"
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		entries, next, err := b.client.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+			Stream:   topic,
+			Group:    b.group,
+			Consumer: b.consumer,
+			MinIdle:  b.minIdle,
+			Start:    cursor,
+			Count:    10,
+		}).Result()
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			continue
+		}
+		cursor = next
+		for _, entry := range entries {
+			msg := b.toMessage(ctx, topic, entry)
+			select {
+			case out <- msg:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// toMessage converts a stream entry to a Message, populating Attempts from
+// the group's own pending-entries delivery count (XPENDING) rather than an
+// in-process counter, so a router restart doesn't reset retries to zero.
+func (b *redisStreamsBroker) toMessage(ctx context.Context, topic string, entry redis.XMessage) Message {
+	payload, _ := entry.Values["payload"].(string)
+	msg := Message{ID: entry.ID, Topic: topic, Payload: []byte(payload), Attempts: b.deliveryAttempts(ctx, topic, entry.ID)}
+	return msg.WithAckFuncs(
+		func(ctx context.Context) error {
+			return b.client.XAck(ctx, topic, b.group, entry.ID).Err()
+		},
+		func(ctx context.Context) error {
+			// Leave the entry in the Pending Entries List unacknowledged;
+			// reclaimLoop's XAUTOCLAIM picks it back up once it has sat
+			// idle for longer than minIdle and redelivers it.
+			return nil
+		},
+	)
+}
+
+// deliveryAttempts returns how many times id has already been delivered to
+// the group, via XPENDING's per-entry retry count, so Attempts survives a
+// redelivery even if this process (and any in-memory counters) restarted
+// since the previous delivery.
+func (b *redisStreamsBroker) deliveryAttempts(ctx context.Context, topic, id string) int {
+	pending, err := b.client.XPendingExt(ctx, &redis.XPendingExtArgs{
+		Stream: topic,
+		Group:  b.group,
+		Start:  id,
+		End:    id,
+		Count:  1,
+	}).Result()
+	if err != nil || len(pending) == 0 {
+		return 0
+	}
+	attempts := int(pending[0].RetryCount) - 1
+	if attempts < 0 {
+		return 0
+	}
+	return attempts
+}
+
+func isBusyGroupErr(err error) bool {
+	return err != nil && err.Error() == "BUSYGROUP Consumer Group name already exists"
+}
+
+func (b *redisStreamsBroker) Close() error {
+	return b.client.Close()
+}
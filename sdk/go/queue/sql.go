@@ -0,0 +1,218 @@
+package queue
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	_ "github.com/go-sql-driver/mysql" // registers the "mysql" database/sql driver
+	_ "github.com/lib/pq"              // registers the "postgres" database/sql driver
+)
+
+// defaultLease is how long a claimed row stays invisible to other
+// consumers before it's considered abandoned and becomes claimable again.
+// It must comfortably exceed a normal handler's run time: it is not an
+// Ack/Nack deadline, just a crash-recovery backstop.
+const defaultLease = 30 * time.Second
+
+// sqlBroker is a Publisher+Subscriber backed by a SQL table, used for both
+// Postgres and MySQL. It polls for available rows and claims one at a time
+// with `FOR UPDATE SKIP LOCKED`, which both engines support, so concurrent
+// consumers never double-process a row. A claim does not delete the row;
+// it pushes available_at out by lease, so a process that crashes or is
+// killed mid-handler leaves the row to fall back into visibility and be
+// redelivered (at-least-once) instead of silently losing it. Only Ack
+// deletes the row.
+type sqlBroker struct {
+	db     *sql.DB
+	driver string // "postgres" | "mysql"
+	table  string
+	lease  time.Duration
+}
+
+// SQLBrokerOption customizes a broker built by NewSQLBroker.
+type SQLBrokerOption func(*sqlBroker)
+
+// WithTable overrides the default queue table name ("queue_jobs").
+func WithTable(name string) SQLBrokerOption {
+	return func(b *sqlBroker) { b.table = name }
+}
+
+// WithLease overrides the default claim visibility timeout (defaultLease).
+func WithLease(d time.Duration) SQLBrokerOption {
+	return func(b *sqlBroker) { b.lease = d }
+}
+
+// NewSQLBroker opens dsn with driverName ("postgres" or "mysql") and returns
+// a Publisher+Subscriber over a jobs table. Callers are expected to have
+// already run the DDL in EnsureSchema (or their own migration) once.
+func NewSQLBroker(driverName, dsn string, opts ...SQLBrokerOption) (*sqlBroker, error) {
+	if driverName != "postgres" && driverName != "mysql" {
+		return nil, fmt.Errorf("queue: unsupported SQL driver %q", driverName)
+	}
+	db, err := sql.Open(driverName, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("queue: opening %s: %w", driverName, err)
+	}
+	b := &sqlBroker{db: db, driver: driverName, table: "queue_jobs", lease: defaultLease}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b, nil
+}
+
+// EnsureSchema creates the jobs table if it does not already exist.
+func (b *sqlBroker) EnsureSchema(ctx context.Context) error {
+	var ddl string
+	switch b.driver {
+	case "postgres":
+		ddl = fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+			id TEXT PRIMARY KEY,
+			topic TEXT NOT NULL,
+			payload BYTEA NOT NULL,
+			attempts INTEGER NOT NULL DEFAULT 0,
+			available_at TIMESTAMPTZ NOT NULL DEFAULT now()
+		)`, b.table)
+	case "mysql":
+		ddl = fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+			id VARCHAR(64) PRIMARY KEY,
+			topic VARCHAR(255) NOT NULL,
+			payload BLOB NOT NULL,
+			attempts INT NOT NULL DEFAULT 0,
+			available_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)`, b.table)
+	}
+	_, err := b.db.ExecContext(ctx, ddl)
+	return err
+}
+
+func (b *sqlBroker) placeholder(n int) string {
+	if b.driver == "postgres" {
+		return fmt.Sprintf("$%d", n)
+	}
+	return "?"
+}
+
+func (b *sqlBroker) Publish(ctx context.Context, topic string, payload []byte) error {
+	id := newMessageID()
+	query := fmt.Sprintf(`INSERT INTO %s (id, topic, payload) VALUES (%s, %s, %s)`,
+		b.table, b.placeholder(1), b.placeholder(2), b.placeholder(3))
+	_, err := b.db.ExecContext(ctx, query, id, topic, payload)
+	if err != nil {
+		return fmt.Errorf("queue: publishing to %q: %w", topic, err)
+	}
+	return nil
+}
+
+// Subscribe polls for available rows on topic every pollInterval and claims
+// one at a time inside a transaction using FOR UPDATE SKIP LOCKED.
+func (b *sqlBroker) Subscribe(ctx context.Context, topic string) (<-chan Message, error) {
+	out := make(chan Message)
+	go func() {
+		defer close(out)
+		ticker := time.NewTicker(pollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				msg, ok, err := b.claimOne(ctx, topic)
+				if err != nil || !ok {
+					continue
+				}
+				select {
+				case out <- msg:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return out, nil
+}
+
+func (b *sqlBroker) claimOne(ctx context.Context, topic string) (Message, bool, error) {
+	tx, err := b.db.BeginTx(ctx, nil)
+	if err != nil {
+		return Message{}, false, err
+	}
+	defer tx.Rollback()
+
+	selectQuery := fmt.Sprintf(`SELECT id, payload, attempts FROM %s WHERE topic = %s AND available_at <= %s
+		ORDER BY available_at ASC LIMIT 1 FOR UPDATE SKIP LOCKED`,
+		b.table, b.placeholder(1), b.now())
+
+	var id string
+	var payload []byte
+	var attempts int
+	row := tx.QueryRowContext(ctx, selectQuery, topic)
+	if err := row.Scan(&id, &payload, &attempts); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Message{}, false, nil
+		}
+		return Message{}, false, err
+	}
+
+	// Claiming extends the lease instead of deleting the row, so a process
+	// that dies before Ack/Nack leaves the row to fall back into
+	// visibility once the lease expires rather than losing it outright.
+	leaseQuery := fmt.Sprintf(`UPDATE %s SET available_at = %s WHERE id = %s`,
+		b.table, b.availableAtExpr(b.lease), b.placeholder(1))
+	if _, err := tx.ExecContext(ctx, leaseQuery, id); err != nil {
+		return Message{}, false, err
+	}
+	if err := tx.Commit(); err != nil {
+		return Message{}, false, err
+	}
+
+	msg := Message{ID: id, Topic: topic, Payload: payload, Attempts: attempts}
+	msg = msg.WithAckFuncs(
+		func(ctx context.Context) error { return b.ack(ctx, id) },
+		func(ctx context.Context) error { return b.requeue(ctx, msg) },
+	)
+	return msg, true, nil
+}
+
+// ack deletes a successfully processed (or terminally dead-lettered) row.
+func (b *sqlBroker) ack(ctx context.Context, id string) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE id = %s`, b.table, b.placeholder(1))
+	_, err := b.db.ExecContext(ctx, query, id)
+	return err
+}
+
+// requeue reopens a Nacked message in place: attempts incremented and
+// available_at pushed out by a linear backoff. The row is never deleted
+// except by ack, so Attempts stays durable across retries and process
+// restarts.
+func (b *sqlBroker) requeue(ctx context.Context, msg Message) error {
+	delay := time.Duration(msg.Attempts+1) * time.Second
+	query := fmt.Sprintf(`UPDATE %s SET attempts = %s, available_at = %s WHERE id = %s`,
+		b.table, b.placeholder(1), b.availableAtExpr(delay), b.placeholder(2))
+	_, err := b.db.ExecContext(ctx, query, msg.Attempts+1, msg.ID)
+	return err
+}
+
+func (b *sqlBroker) now() string {
+	if b.driver == "postgres" {
+		return "now()"
+	}
+	return "NOW()"
+}
+
+// availableAtExpr returns a SQL expression for "now + delay" in this
+// broker's dialect, for use directly in an UPDATE statement.
+func (b *sqlBroker) availableAtExpr(delay time.Duration) string {
+	switch b.driver {
+	case "postgres":
+		return fmt.Sprintf("now() + interval '%d seconds'", int(delay.Seconds()))
+	default: // mysql
+		return fmt.Sprintf("DATE_ADD(NOW(), INTERVAL %d SECOND)", int(delay.Seconds()))
+	}
+}
+
+func (b *sqlBroker) Close() error {
+	return b.db.Close()
+}
@@ -0,0 +1,46 @@
+package queue
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSQLBrokerPlaceholder(t *testing.T) {
+	pg := &sqlBroker{driver: "postgres"}
+	if got := pg.placeholder(2); got != "$2" {
+		t.Fatalf("postgres placeholder(2) = %q, want $2", got)
+	}
+	my := &sqlBroker{driver: "mysql"}
+	if got := my.placeholder(2); got != "?" {
+		t.Fatalf("mysql placeholder(2) = %q, want ?", got)
+	}
+}
+
+func TestSQLBrokerAvailableAtExpr(t *testing.T) {
+	pg := &sqlBroker{driver: "postgres"}
+	if got := pg.availableAtExpr(5 * time.Second); got != "now() + interval '5 seconds'" {
+		t.Fatalf("postgres availableAtExpr = %q", got)
+	}
+	my := &sqlBroker{driver: "mysql"}
+	if got := my.availableAtExpr(5 * time.Second); got != "DATE_ADD(NOW(), INTERVAL 5 SECOND)" {
+		t.Fatalf("mysql availableAtExpr = %q", got)
+	}
+}
+
+func TestNewSQLBrokerRejectsUnsupportedDriver(t *testing.T) {
+	if _, err := NewSQLBroker("sqlite", ":memory:"); err == nil {
+		t.Fatal("NewSQLBroker accepted an unsupported driver name")
+	}
+}
+
+func TestSQLBrokerOptionsApplyDefaults(t *testing.T) {
+	b := &sqlBroker{table: "queue_jobs", lease: defaultLease}
+	WithTable("custom_jobs")(b)
+	WithLease(90 * time.Second)(b)
+	if b.table != "custom_jobs" {
+		t.Fatalf("table = %q, want custom_jobs", b.table)
+	}
+	if b.lease != 90*time.Second {
+		t.Fatalf("lease = %v, want 90s", b.lease)
+	}
+}
@@ -0,0 +1,45 @@
+package aisdk
+
+import (
+	"fmt"
+
+	"github.com/lippytm/ai-time-machines/go-service/sdk/go/queue"
+)
+
+// queueBroker implements both queue.Publisher and queue.Subscriber; every
+// driver NewBroker can return already satisfies it.
+type queueBroker interface {
+	queue.Publisher
+	queue.Subscriber
+}
+
+// Broker builds the queue.Publisher+Subscriber for s.Queue.Driver.
+func (s *SDK) Broker() (queueBroker, error) {
+	if err := s.Queue.Validate(); err != nil {
+		return nil, err
+	}
+	switch s.Queue.Driver {
+	case "sql":
+		return queue.NewSQLBroker(s.Queue.SQLDriver, s.Queue.DSN)
+	case "redis":
+		return queue.NewRedisStreamsBroker(s.Queue.DSN, s.Queue.HandlerName, queue.NewConsumerName(s.Queue.HandlerName)), nil
+	case "amqp":
+		return queue.NewAMQPBroker(s.Queue.DSN)
+	default:
+		return nil, fmt.Errorf("aisdk: unknown queue driver %q", s.Queue.Driver)
+	}
+}
+
+// JobRouter builds a queue.JobRouter over s.Broker(), preconfigured from
+// s.Queue's DeadLetterQueue/MaxRetries/HandlerName.
+func (s *SDK) JobRouter() (*queue.JobRouter, error) {
+	broker, err := s.Broker()
+	if err != nil {
+		return nil, err
+	}
+	return queue.NewJobRouter(broker, broker, queue.RouterConfig{
+		DeadLetterTopic: s.Queue.DeadLetterQueue,
+		MaxRetries:      s.Queue.MaxRetries,
+		HandlerName:     s.Queue.HandlerName,
+	}), nil
+}
@@ -0,0 +1,182 @@
+package aisdk
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/lippytm/ai-time-machines/go-service/sdk/go/vector"
+)
+
+// RAGOption customizes a RAGPipeline built by NewRAGPipeline.
+type RAGOption func(*RAGPipeline)
+
+// WithChunkSize overrides the default chunk size (in runes, 1000).
+func WithChunkSize(n int) RAGOption {
+	return func(p *RAGPipeline) { p.chunkSize = n }
+}
+
+// WithChunkOverlap overrides the default overlap between consecutive chunks
+// (in runes, 200).
+func WithChunkOverlap(n int) RAGOption {
+	return func(p *RAGPipeline) { p.chunkOverlap = n }
+}
+
+// RAGPipeline chunks text, embeds it, and keeps it in a vector.Store, then
+// retrieves the most relevant chunks for a query as ready-to-splice context
+// strings.
+type RAGPipeline struct {
+	store        vector.Store
+	embedder     vector.Embedder
+	chunkSize    int
+	chunkOverlap int
+}
+
+// NewRAGPipeline builds a RAGPipeline over store using embedder to turn text
+// into vectors.
+func NewRAGPipeline(store vector.Store, embedder vector.Embedder, opts ...RAGOption) *RAGPipeline {
+	p := &RAGPipeline{
+		store:        store,
+		embedder:     embedder,
+		chunkSize:    1000,
+		chunkOverlap: 200,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Ingest splits text into overlapping chunks, embeds each, and upserts them
+// into the store under IDs derived from docID so re-ingesting the same
+// document overwrites its previous chunks instead of duplicating them.
+func (p *RAGPipeline) Ingest(ctx context.Context, docID, text string) error {
+	chunks := chunkText(text, p.chunkSize, p.chunkOverlap)
+	if len(chunks) == 0 {
+		return nil
+	}
+	embeddings, err := p.embedder.Embed(ctx, chunks)
+	if err != nil {
+		return fmt.Errorf("aisdk: embedding %q: %w", docID, err)
+	}
+	vectors := make([]vector.Vector, len(chunks))
+	for i, chunk := range chunks {
+		vectors[i] = vector.Vector{
+			ID:     chunkID(docID, i),
+			Values: embeddings[i],
+			Metadata: map[string]interface{}{
+				"text":   chunk,
+				"doc_id": docID,
+				"chunk":  i,
+			},
+		}
+	}
+	if err := p.store.Upsert(ctx, vectors); err != nil {
+		return fmt.Errorf("aisdk: upserting %q: %w", docID, err)
+	}
+	return nil
+}
+
+// Retrieve embeds query and returns the text of the k most relevant chunks,
+// ready to splice into a chat prompt.
+func (p *RAGPipeline) Retrieve(ctx context.Context, query string, k int) ([]string, error) {
+	embeddings, err := p.embedder.Embed(ctx, []string{query})
+	if err != nil {
+		return nil, fmt.Errorf("aisdk: embedding query: %w", err)
+	}
+	matches, err := p.store.Query(ctx, embeddings[0], k, nil)
+	if err != nil {
+		return nil, fmt.Errorf("aisdk: querying store: %w", err)
+	}
+	out := make([]string, 0, len(matches))
+	for _, m := range matches {
+		if text, ok := m.Metadata["text"].(string); ok {
+			out = append(out, text)
+		}
+	}
+	return out, nil
+}
+
+// chunkText splits text into overlapping windows of size runes, stepping by
+// size-overlap each time. size is clamped to at least 1 and overlap is
+// clamped into [0, size) so step is always >= 1 and the loop always
+// advances, regardless of what a caller passes to WithChunkSize/
+// WithChunkOverlap.
+func chunkText(text string, size, overlap int) []string {
+	runes := []rune(text)
+	if len(runes) == 0 {
+		return nil
+	}
+	if size < 1 {
+		size = 1
+	}
+	if overlap < 0 || overlap >= size {
+		overlap = size / 2
+	}
+	step := size - overlap
+	var chunks []string
+	for start := 0; start < len(runes); start += step {
+		end := start + size
+		if end > len(runes) {
+			end = len(runes)
+		}
+		chunks = append(chunks, strings.TrimSpace(string(runes[start:end])))
+		if end == len(runes) {
+			break
+		}
+	}
+	return chunks
+}
+
+func chunkID(docID string, index int) string {
+	sum := sha1.Sum([]byte(fmt.Sprintf("%s:%d", docID, index)))
+	return hex.EncodeToString(sum[:])
+}
+
+// VectorClient builds the vector.Store driver for s.VectorStore.Provider.
+func (s *SDK) VectorClient(ctx context.Context) (vector.Store, error) {
+	return newVectorStore(ctx, s.VectorStore, nil)
+}
+
+func newVectorStore(ctx context.Context, cfg *VectorStoreConfig, httpClient *http.Client) (vector.Store, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("aisdk: nil VectorStoreConfig")
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	switch cfg.Provider {
+	case "pinecone":
+		return vector.NewPineconeStore(cfg.APIKey, cfg.Endpoint, httpClient), nil
+	case "weaviate":
+		return vector.NewWeaviateStore(cfg.Endpoint, cfg.APIKey, cfg.IndexName, httpClient), nil
+	case "chroma":
+		// Chroma's data-plane endpoints are keyed by the collection's
+		// server-assigned UUID, not its name, so IndexName (a name) has to
+		// be resolved to that UUID before the store can reach it.
+		collectionID, err := vector.ResolveChromaCollectionID(ctx, cfg.Endpoint, cfg.IndexName, httpClient)
+		if err != nil {
+			return nil, fmt.Errorf("aisdk: resolving chroma collection %q: %w", cfg.IndexName, err)
+		}
+		return vector.NewChromaStore(cfg.Endpoint, cfg.IndexName, collectionID, httpClient), nil
+	default:
+		return nil, fmt.Errorf("aisdk: unknown vector store provider %q", cfg.Provider)
+	}
+}
+
+// RAG builds the RAGPipeline for this SDK instance: an Embedder backed by
+// s.AI and a Store backed by s.VectorStore.
+func (s *SDK) RAG(ctx context.Context, opts ...RAGOption) (*RAGPipeline, error) {
+	embedder, err := NewEmbedder(s.AI)
+	if err != nil {
+		return nil, err
+	}
+	store, err := newVectorStore(ctx, s.VectorStore, nil)
+	if err != nil {
+		return nil, err
+	}
+	return NewRAGPipeline(store, embedder, opts...), nil
+}
@@ -0,0 +1,39 @@
+package aisdk
+
+import (
+	"testing"
+	"time"
+)
+
+func TestChunkTextGuardsAgainstNonPositiveSizeAndOverlap(t *testing.T) {
+	text := make([]rune, 50)
+	for i := range text {
+		text[i] = 'a'
+	}
+
+	cases := []struct {
+		name    string
+		size    int
+		overlap int
+	}{
+		{"zero size", 0, 0},
+		{"negative size", -5, 0},
+		{"negative overlap", 10, -3},
+		{"overlap equal to size", 10, 10},
+		{"overlap greater than size", 10, 25},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			done := make(chan []string, 1)
+			go func() { done <- chunkText(string(text), c.size, c.overlap) }()
+			select {
+			case chunks := <-done:
+				if len(chunks) == 0 {
+					t.Fatal("chunkText returned no chunks for non-empty text")
+				}
+			case <-time.After(time.Second):
+				t.Fatal("chunkText did not return, likely looping forever")
+			}
+		})
+	}
+}
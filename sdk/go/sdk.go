@@ -5,15 +5,21 @@ package aisdk
 import (
 	"errors"
 	"os"
+	"strconv"
 )
 
 // AIProviderConfig manages AI provider configuration
 // Supports OpenAI, Hugging Face, and other AI providers
+// APIKey defaults from AI_API_KEY; see NewSDKWithSecrets to resolve it from
+// a SecretProvider instead.
 type AIProviderConfig struct {
 	Provider string // "openai" | "huggingface" | "custom"
 	APIKey   string
 	Model    string
-	// TODO: Load from secure secret manager
+	// BaseURL overrides the provider's default API endpoint. Required for
+	// Provider == "custom"; optional elsewhere (e.g. proxies, self-hosted
+	// OpenAI-compatible gateways).
+	BaseURL string
 }
 
 // NewAIProviderConfig creates a new AI provider configuration
@@ -31,6 +37,7 @@ func NewAIProviderConfig(provider, apiKey, model string) *AIProviderConfig {
 		Provider: provider,
 		APIKey:   apiKey,
 		Model:    model,
+		BaseURL:  os.Getenv("AI_BASE_URL"),
 	}
 }
 
@@ -39,17 +46,24 @@ func (c *AIProviderConfig) Validate() error {
 	if c.APIKey == "" {
 		return errors.New("AI_API_KEY not configured. Set via environment or constructor")
 	}
+	if c.Provider == "custom" && c.BaseURL == "" {
+		return errors.New("AI_BASE_URL not configured for custom provider")
+	}
 	return nil
 }
 
 // VectorStoreConfig manages vector store configuration
 // Supports Pinecone, Weaviate, and Chroma
+// APIKey defaults from VECTOR_STORE_API_KEY; see NewSDKWithSecrets to
+// resolve it from a SecretProvider instead.
 type VectorStoreConfig struct {
 	Provider    string // "pinecone" | "weaviate" | "chroma"
 	APIKey      string
 	Environment string
 	IndexName   string
-	// TODO: Load from secure secret manager
+	// Endpoint is the provider's data-plane address: a Pinecone index host,
+	// a Weaviate base URL, or a Chroma base URL.
+	Endpoint string
 }
 
 // NewVectorStoreConfig creates a new vector store configuration
@@ -71,6 +85,7 @@ func NewVectorStoreConfig(provider, apiKey, environment, indexName string) *Vect
 		APIKey:      apiKey,
 		Environment: environment,
 		IndexName:   indexName,
+		Endpoint:    os.Getenv("VECTOR_STORE_ENDPOINT"),
 	}
 }
 
@@ -79,18 +94,22 @@ func (c *VectorStoreConfig) Validate() error {
 	if c.APIKey == "" {
 		return errors.New("VECTOR_STORE_API_KEY not configured")
 	}
+	if c.Endpoint == "" {
+		return errors.New("VECTOR_STORE_ENDPOINT not configured")
+	}
 	return nil
 }
 
 // Web3Config manages Web3 provider configuration
 // Supports Ethereum (EVM) and Solana chains
+// PrivateKey defaults from WEB3_PRIVATE_KEY; see NewSDKWithSecrets to
+// resolve it from a SecretProvider instead.
+// TODO: Add support for additional chains (see extension points in README)
 type Web3Config struct {
 	Chain      string // "ethereum" | "solana" | "polygon" | etc
 	RPCUrl     string
 	PrivateKey string
 	Network    string // "mainnet" | "testnet" | "devnet"
-	// TODO: Load from secure secret manager
-	// TODO: Add support for additional chains (see extension points in README)
 }
 
 // NewWeb3Config creates a new Web3 configuration
@@ -123,13 +142,21 @@ func (c *Web3Config) Validate() error {
 	return nil
 }
 
+// Client builds the Web3Client for c, dialing c.RPCUrl and loading
+// c.PrivateKey for signing. The concrete implementation is chosen from
+// c.Chain/c.Network via the chain registry in web3client.go.
+func (c *Web3Config) Client() (Web3Client, error) {
+	return NewWeb3Client(c)
+}
+
 // MessagingConfig manages messaging provider configuration
 // Supports Slack and Discord
+// Token defaults from MESSAGING_TOKEN; see NewSDKWithSecrets to resolve it
+// from a SecretProvider instead.
 type MessagingConfig struct {
 	Provider string // "slack" | "discord"
 	Token    string
 	Channel  string
-	// TODO: Load from secure secret manager
 }
 
 // NewMessagingConfig creates a new messaging configuration
@@ -160,12 +187,13 @@ func (c *MessagingConfig) Validate() error {
 
 // DataStorageConfig manages data storage configuration
 // Supports Postgres, Redis, S3, and IPFS
+// ConnectionString defaults from DATABASE_URL; see NewSDKWithSecrets to
+// resolve it from a SecretProvider instead.
 type DataStorageConfig struct {
 	Type             string // "postgres" | "redis" | "s3" | "ipfs"
 	ConnectionString string
 	Bucket           string
 	Region           string
-	// TODO: Load from secure secret manager
 }
 
 // NewDataStorageConfig creates a new data storage configuration
@@ -201,6 +229,102 @@ func (c *DataStorageConfig) Validate() error {
 	return nil
 }
 
+// QueueConfig manages async job queue configuration
+// Supports SQL (Postgres/MySQL), Redis Streams, and AMQP
+type QueueConfig struct {
+	Driver string // "sql" | "redis" | "amqp"
+	// DSN is the broker connection string. For Driver == "sql" this is left
+	// empty and NewSDK wires it to DataStorage.ConnectionString instead, so
+	// the same database doubles as broker storage.
+	DSN string
+	// SQLDriver selects the database/sql driver ("postgres" | "mysql") when
+	// Driver == "sql".
+	SQLDriver       string
+	DeadLetterQueue string
+	MaxRetries      int
+	HandlerName     string
+}
+
+// NewQueueConfig creates a new queue configuration
+func NewQueueConfig(driver, dsn string) *QueueConfig {
+	if driver == "" {
+		driver = "sql"
+	}
+	if dsn == "" {
+		dsn = os.Getenv("QUEUE_DSN")
+	}
+	maxRetries := 5
+	if v := os.Getenv("MAX_RETRIES"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			maxRetries = n
+		}
+	}
+	return &QueueConfig{
+		Driver:          driver,
+		DSN:             dsn,
+		SQLDriver:       "postgres",
+		DeadLetterQueue: os.Getenv("DEAD_LETTER_QUEUE"),
+		MaxRetries:      maxRetries,
+		HandlerName:     os.Getenv("HANDLER_NAME"),
+	}
+}
+
+// Validate checks if the configuration is valid
+func (c *QueueConfig) Validate() error {
+	if c.Driver == "sql" && c.DSN == "" {
+		return errors.New("QUEUE_DSN not configured for sql queue driver (or DataStorage.ConnectionString, when DataStorage.Type is postgres)")
+	}
+	if c.Driver != "sql" && c.DSN == "" {
+		return errors.New("QUEUE_DSN not configured")
+	}
+	return nil
+}
+
+// PaymentsConfig manages state-channel micropayment configuration for
+// metering AI inference calls off-chain. See sdk/go/payments for the
+// channel implementation and sdk/go/payments/config.go for what each
+// contract address names. ChainPK defaults from PAYMENTS_CHAIN_PK; see
+// NewSDKWithSecrets to resolve it from a SecretProvider instead.
+type PaymentsConfig struct {
+	NAAddress  string
+	VPAAddress string
+	CAAddress  string
+	ChainPK    string
+}
+
+// NewPaymentsConfig creates a new payments configuration.
+func NewPaymentsConfig(naAddress, vpaAddress, caAddress, chainPK string) *PaymentsConfig {
+	if naAddress == "" {
+		naAddress = os.Getenv("PAYMENTS_NA_ADDRESS")
+	}
+	if vpaAddress == "" {
+		vpaAddress = os.Getenv("PAYMENTS_VPA_ADDRESS")
+	}
+	if caAddress == "" {
+		caAddress = os.Getenv("PAYMENTS_CA_ADDRESS")
+	}
+	if chainPK == "" {
+		chainPK = os.Getenv("PAYMENTS_CHAIN_PK")
+	}
+	return &PaymentsConfig{
+		NAAddress:  naAddress,
+		VPAAddress: vpaAddress,
+		CAAddress:  caAddress,
+		ChainPK:    chainPK,
+	}
+}
+
+// Validate checks if the configuration is valid
+func (c *PaymentsConfig) Validate() error {
+	if c.ChainPK == "" {
+		return errors.New("PAYMENTS_CHAIN_PK not configured")
+	}
+	if c.NAAddress == "" {
+		return errors.New("PAYMENTS_NA_ADDRESS not configured")
+	}
+	return nil
+}
+
 // SDK is the main SDK factory that creates and manages all provider configurations
 type SDK struct {
 	AI          *AIProviderConfig
@@ -208,40 +332,48 @@ type SDK struct {
 	Web3        *Web3Config
 	Messaging   *MessagingConfig
 	DataStorage *DataStorageConfig
+	Queue       *QueueConfig
+	Payments    *PaymentsConfig
 }
 
 // NewSDK creates a new SDK instance with default configurations
 func NewSDK() *SDK {
+	dataStorage := NewDataStorageConfig("", "", "", "")
+	queue := NewQueueConfig("", "")
+	if queue.Driver == "sql" && queue.DSN == "" && dataStorage.Type == "postgres" {
+		// The same Postgres database doubles as broker storage unless the
+		// caller points QUEUE_DSN somewhere else.
+		queue.DSN = dataStorage.ConnectionString
+	}
 	return &SDK{
 		AI:          NewAIProviderConfig("", "", ""),
 		VectorStore: NewVectorStoreConfig("", "", "", ""),
 		Web3:        NewWeb3Config("", "", "", ""),
 		Messaging:   NewMessagingConfig("", "", ""),
-		DataStorage: NewDataStorageConfig("", "", "", ""),
+		DataStorage: dataStorage,
+		Queue:       queue,
+		Payments:    NewPaymentsConfig("", "", "", ""),
 	}
 }
 
-// ValidateAll validates all configurations
-func (s *SDK) ValidateAll() bool {
-	if err := s.AI.Validate(); err != nil {
-		return false
-	}
-	if err := s.VectorStore.Validate(); err != nil {
-		return false
-	}
-	if err := s.Web3.Validate(); err != nil {
-		return false
-	}
-	if err := s.Messaging.Validate(); err != nil {
-		return false
-	}
-	if err := s.DataStorage.Validate(); err != nil {
-		return false
-	}
-	return true
+// ValidateAll validates every configuration and returns a single error
+// joining every validation failure (via errors.Join), or nil if every
+// configuration is valid. Use errors.Is/errors.As or simply print the
+// result to see every missing key at once, rather than just the first.
+func (s *SDK) ValidateAll() error {
+	return errors.Join(
+		s.AI.Validate(),
+		s.VectorStore.Validate(),
+		s.Web3.Validate(),
+		s.Messaging.Validate(),
+		s.DataStorage.Validate(),
+		s.Queue.Validate(),
+		s.Payments.Validate(),
+	)
 }
 
-// TODO: Add provider initialization methods
-// TODO: Add connection pooling
-// TODO: Add retry logic
-// TODO: Add monitoring/logging hooks
+// AIClient builds the AIClient for s.AI, pooling connections and applying
+// retry/backoff/logging/metrics per opts. See NewAIClient.
+func (s *SDK) AIClient(opts ...ClientOption) (AIClient, error) {
+	return NewAIClient(s.AI, opts...)
+}
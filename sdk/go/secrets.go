@@ -0,0 +1,131 @@
+package aisdk
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/joho/godotenv"
+)
+
+// SecretProvider resolves a configuration key to its current value from
+// wherever secrets actually live: process environment variables, a Vault
+// KV v2 mount, AWS Secrets Manager, GCP Secret Manager, or a .env file.
+// Every New*Config constructor's `TODO: Load from secure secret manager`
+// is resolved by routing through a SecretProvider instead of os.Getenv
+// directly; see NewSDKWithSecrets.
+type SecretProvider interface {
+	Get(ctx context.Context, key string) (string, error)
+}
+
+// EnvSecretProvider resolves keys from process environment variables,
+// matching the SDK's original os.Getenv-based behavior.
+type EnvSecretProvider struct{}
+
+// Get returns os.LookupEnv(key)'s value, or an error if key is unset.
+func (EnvSecretProvider) Get(_ context.Context, key string) (string, error) {
+	v, ok := os.LookupEnv(key)
+	if !ok {
+		return "", fmt.Errorf("aisdk: environment variable %q not set", key)
+	}
+	return v, nil
+}
+
+// FileSecretProvider resolves keys from a .env-format file, read with the
+// already-vendored joho/godotenv.
+type FileSecretProvider struct {
+	values map[string]string
+}
+
+// NewFileSecretProvider reads path (a .env-format file) once and serves
+// every subsequent Get from the parsed result.
+func NewFileSecretProvider(path string) (*FileSecretProvider, error) {
+	values, err := godotenv.Read(path)
+	if err != nil {
+		return nil, fmt.Errorf("aisdk: reading secrets file %q: %w", path, err)
+	}
+	return &FileSecretProvider{values: values}, nil
+}
+
+// Get returns the value of key as parsed from the file, or an error if key
+// is absent.
+func (p *FileSecretProvider) Get(_ context.Context, key string) (string, error) {
+	v, ok := p.values[key]
+	if !ok {
+		return "", fmt.Errorf("aisdk: key %q not present in secrets file", key)
+	}
+	return v, nil
+}
+
+// WithPrefix wraps provider so every key is resolved as prefix+key instead
+// of key, letting one backend namespace secrets for multiple deployments
+// (e.g. "prod/AI_API_KEY" vs. "staging/AI_API_KEY") under a single SDK
+// integration.
+func WithPrefix(provider SecretProvider, prefix string) SecretProvider {
+	return prefixedProvider{provider: provider, prefix: prefix}
+}
+
+type prefixedProvider struct {
+	provider SecretProvider
+	prefix   string
+}
+
+func (p prefixedProvider) Get(ctx context.Context, key string) (string, error) {
+	return p.provider.Get(ctx, p.prefix+key)
+}
+
+// WithTTLCache wraps provider so each key's resolved value is reused for
+// ttl before being re-fetched, instead of hitting the backend on every
+// Get. This matters for backends with rate limits or per-call cost (Vault,
+// AWS Secrets Manager, GCP Secret Manager); errors are never cached, so a
+// transient failure doesn't stick for the life of the TTL.
+func WithTTLCache(provider SecretProvider, ttl time.Duration) SecretProvider {
+	return &ttlCachedProvider{provider: provider, ttl: ttl, entries: make(map[string]ttlEntry)}
+}
+
+type ttlEntry struct {
+	value     string
+	fetchedAt time.Time
+}
+
+type ttlCachedProvider struct {
+	provider SecretProvider
+	ttl      time.Duration
+
+	mu      sync.Mutex
+	entries map[string]ttlEntry
+}
+
+func (p *ttlCachedProvider) Get(ctx context.Context, key string) (string, error) {
+	p.mu.Lock()
+	entry, ok := p.entries[key]
+	p.mu.Unlock()
+	if ok && time.Since(entry.fetchedAt) < p.ttl {
+		return entry.value, nil
+	}
+
+	value, err := p.provider.Get(ctx, key)
+	if err != nil {
+		return "", err
+	}
+
+	p.mu.Lock()
+	p.entries[key] = ttlEntry{value: value, fetchedAt: time.Now()}
+	p.mu.Unlock()
+	return value, nil
+}
+
+// lookup resolves key via provider, returning "" instead of an error when
+// the key is absent so callers can apply the same empty-value defaulting
+// behavior New*Config's os.Getenv-based constructors already use. Callers
+// that require the key rely on the resulting config's Validate() to catch
+// the empty value instead.
+func lookup(ctx context.Context, provider SecretProvider, key string) string {
+	v, err := provider.Get(ctx, key)
+	if err != nil {
+		return ""
+	}
+	return v
+}
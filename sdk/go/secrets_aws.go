@@ -0,0 +1,34 @@
+package aisdk
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+)
+
+// AWSSecretsManagerProvider resolves keys against AWS Secrets Manager. Each
+// key is treated as a secret ID (name or ARN) holding a plain-string
+// SecretString; use key-prefix namespacing (WithPrefix) if a deployment
+// stores secrets under a shared path.
+type AWSSecretsManagerProvider struct {
+	client *secretsmanager.Client
+}
+
+// NewAWSSecretsManagerProvider builds an AWSSecretsManagerProvider over
+// client.
+func NewAWSSecretsManagerProvider(client *secretsmanager.Client) *AWSSecretsManagerProvider {
+	return &AWSSecretsManagerProvider{client: client}
+}
+
+// Get fetches key's current SecretString from Secrets Manager.
+func (p *AWSSecretsManagerProvider) Get(ctx context.Context, key string) (string, error) {
+	out, err := p.client.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{SecretId: &key})
+	if err != nil {
+		return "", fmt.Errorf("aisdk: fetching AWS secret %q: %w", key, err)
+	}
+	if out.SecretString == nil {
+		return "", fmt.Errorf("aisdk: AWS secret %q has no SecretString", key)
+	}
+	return *out.SecretString, nil
+}
@@ -0,0 +1,36 @@
+package aisdk
+
+import (
+	"context"
+	"fmt"
+
+	secretmanager "cloud.google.com/go/secretmanager/apiv1"
+	"cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+)
+
+// GCPSecretManagerProvider resolves keys against GCP Secret Manager. Each
+// key is the secret's short name within project; the "latest" version is
+// always read.
+type GCPSecretManagerProvider struct {
+	client  *secretmanager.Client
+	project string
+}
+
+// NewGCPSecretManagerProvider builds a GCPSecretManagerProvider over client
+// for secrets in project.
+func NewGCPSecretManagerProvider(client *secretmanager.Client, project string) *GCPSecretManagerProvider {
+	return &GCPSecretManagerProvider{client: client, project: project}
+}
+
+// Get fetches the latest version of key from GCP Secret Manager.
+func (p *GCPSecretManagerProvider) Get(ctx context.Context, key string) (string, error) {
+	name := fmt.Sprintf("projects/%s/secrets/%s/versions/latest", p.project, key)
+	resp, err := p.client.AccessSecretVersion(ctx, &secretmanagerpb.AccessSecretVersionRequest{Name: name})
+	if err != nil {
+		return "", fmt.Errorf("aisdk: fetching GCP secret %q: %w", key, err)
+	}
+	if resp.Payload == nil {
+		return "", fmt.Errorf("aisdk: GCP secret %q has no payload", key)
+	}
+	return string(resp.Payload.Data), nil
+}
@@ -0,0 +1,107 @@
+package aisdk
+
+import (
+	"context"
+	"strconv"
+)
+
+// Option customizes NewSDKWithSecrets.
+type Option func(*secretsOptions)
+
+type secretsOptions struct {
+	// fallbackToEnv causes lookups that fail against provider to retry
+	// against the process environment before giving up, so a partially
+	// configured secret backend doesn't block every other field.
+	fallbackToEnv bool
+}
+
+func defaultSecretsOptions() *secretsOptions {
+	return &secretsOptions{}
+}
+
+// WithEnvFallback causes every secret lookup that fails against the given
+// SecretProvider to retry against the process environment (the same
+// variables New*Config's constructors read) before being treated as unset.
+// Useful when migrating a deployment onto a secret manager incrementally.
+func WithEnvFallback() Option {
+	return func(o *secretsOptions) { o.fallbackToEnv = true }
+}
+
+// NewSDKWithSecrets builds an SDK resolving every configuration field
+// through provider instead of os.Getenv, using the same bare key names the
+// New*Config constructors fall back to (e.g. "AI_API_KEY",
+// "WEB3_RPC_URL"). Wrap provider with WithPrefix for key-prefix
+// namespacing, and with WithTTLCache for periodic re-fetch; both compose
+// with any SecretProvider. A VaultSecretProvider needs its defaultPath set
+// (see NewVaultSecretProvider) so these bare keys resolve, since Vault
+// otherwise requires the "path#field" form.
+//
+// The returned *SDK is always non-nil so the caller can inspect exactly
+// which fields resolved; the returned error is the result of ValidateAll
+// (every validation failure joined together), or nil if every
+// configuration is valid.
+func NewSDKWithSecrets(ctx context.Context, provider SecretProvider, opts ...Option) (*SDK, error) {
+	o := defaultSecretsOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+	get := func(key string) string {
+		v := lookup(ctx, provider, key)
+		if v == "" && o.fallbackToEnv {
+			v = lookup(ctx, EnvSecretProvider{}, key)
+		}
+		return v
+	}
+
+	ai := NewAIProviderConfig("", "", "")
+	ai.APIKey = get("AI_API_KEY")
+	ai.BaseURL = get("AI_BASE_URL")
+
+	vectorStore := NewVectorStoreConfig("", "", "", "")
+	vectorStore.APIKey = get("VECTOR_STORE_API_KEY")
+	vectorStore.Environment = get("VECTOR_STORE_ENV")
+	vectorStore.Endpoint = get("VECTOR_STORE_ENDPOINT")
+
+	web3 := NewWeb3Config("", "", "", "")
+	web3.RPCUrl = get("WEB3_RPC_URL")
+	web3.PrivateKey = get("WEB3_PRIVATE_KEY")
+
+	messaging := NewMessagingConfig("", "", "")
+	messaging.Token = get("MESSAGING_TOKEN")
+	messaging.Channel = get("MESSAGING_CHANNEL")
+
+	dataStorage := NewDataStorageConfig("", "", "", "")
+	dataStorage.ConnectionString = get("DATABASE_URL")
+	dataStorage.Bucket = get("S3_BUCKET")
+	dataStorage.Region = get("AWS_REGION")
+
+	queue := NewQueueConfig("", "")
+	queue.DSN = get("QUEUE_DSN")
+	queue.DeadLetterQueue = get("DEAD_LETTER_QUEUE")
+	queue.HandlerName = get("HANDLER_NAME")
+	if v := get("MAX_RETRIES"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			queue.MaxRetries = n
+		}
+	}
+	if queue.Driver == "sql" && queue.DSN == "" && dataStorage.Type == "postgres" {
+		queue.DSN = dataStorage.ConnectionString
+	}
+
+	payments := NewPaymentsConfig("", "", "", "")
+	payments.NAAddress = get("PAYMENTS_NA_ADDRESS")
+	payments.VPAAddress = get("PAYMENTS_VPA_ADDRESS")
+	payments.CAAddress = get("PAYMENTS_CA_ADDRESS")
+	payments.ChainPK = get("PAYMENTS_CHAIN_PK")
+
+	sdk := &SDK{
+		AI:          ai,
+		VectorStore: vectorStore,
+		Web3:        web3,
+		Messaging:   messaging,
+		DataStorage: dataStorage,
+		Queue:       queue,
+		Payments:    payments,
+	}
+	return sdk, sdk.ValidateAll()
+}
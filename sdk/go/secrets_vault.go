@@ -0,0 +1,52 @@
+package aisdk
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	vaultapi "github.com/hashicorp/vault/api"
+)
+
+// VaultSecretProvider resolves keys against a HashiCorp Vault KV v2 mount.
+// Keys may be "path#field", e.g. "aisdk/ai#api_key" reads the "api_key"
+// property of the secret at mountPath/aisdk/ai. Bare keys with no "#" (what
+// NewSDKWithSecrets looks up, e.g. "AI_API_KEY") resolve against defaultPath
+// instead, so a single Vault secret can back every field the SDK resolves.
+type VaultSecretProvider struct {
+	kv          *vaultapi.KVv2
+	defaultPath string
+}
+
+// NewVaultSecretProvider builds a VaultSecretProvider over client's KV v2
+// engine mounted at mountPath (e.g. "secret"). defaultPath is the path bare
+// (no "#") keys resolve against; pass "" to require every key be explicitly
+// "path#field".
+func NewVaultSecretProvider(client *vaultapi.Client, mountPath, defaultPath string) *VaultSecretProvider {
+	return &VaultSecretProvider{kv: client.KVv2(mountPath), defaultPath: defaultPath}
+}
+
+// Get reads key from Vault: "path#field" reads field from the secret at
+// path; a bare key reads that field from defaultPath.
+func (p *VaultSecretProvider) Get(ctx context.Context, key string) (string, error) {
+	path, field, ok := strings.Cut(key, "#")
+	if !ok {
+		if p.defaultPath == "" {
+			return "", fmt.Errorf("aisdk: vault key %q must be formatted \"path#field\" (no defaultPath configured)", key)
+		}
+		path, field = p.defaultPath, key
+	}
+	secret, err := p.kv.Get(ctx, path)
+	if err != nil {
+		return "", fmt.Errorf("aisdk: reading vault secret %q: %w", path, err)
+	}
+	raw, ok := secret.Data[field]
+	if !ok {
+		return "", fmt.Errorf("aisdk: vault secret %q has no field %q", path, field)
+	}
+	value, ok := raw.(string)
+	if !ok {
+		return "", fmt.Errorf("aisdk: vault secret %q field %q is not a string", path, field)
+	}
+	return value, nil
+}
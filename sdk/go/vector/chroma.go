@@ -0,0 +1,123 @@
+package vector
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// chromaStore drives a Chroma collection via its REST API.
+type chromaStore struct {
+	baseURL      string
+	collection   string
+	collectionID string
+	httpClient   *http.Client
+}
+
+// NewChromaStore builds a Store backed by a Chroma collection. collectionID
+// is Chroma's server-assigned UUID for collection; resolve it once (e.g. via
+// ResolveChromaCollectionID) and pass it in, since every data-plane Chroma
+// endpoint is keyed by ID rather than name.
+func NewChromaStore(baseURL, collection, collectionID string, httpClient *http.Client) Store {
+	return &chromaStore{
+		baseURL:      strings.TrimRight(baseURL, "/"),
+		collection:   collection,
+		collectionID: collectionID,
+		httpClient:   newHTTPClient(httpClient),
+	}
+}
+
+// ResolveChromaCollectionID looks up collection's server-assigned UUID via
+// GET /api/v1/collections/{name}, for callers (e.g. NewChromaStore) that
+// only have the human-readable name on hand.
+func ResolveChromaCollectionID(ctx context.Context, baseURL, collection string, httpClient *http.Client) (string, error) {
+	var resp chromaGetCollectionResponse
+	url := strings.TrimRight(baseURL, "/") + "/api/v1/collections/" + collection
+	if err := doJSON(ctx, newHTTPClient(httpClient), http.MethodGet, url, nil, nil, &resp); err != nil {
+		return "", err
+	}
+	return resp.ID, nil
+}
+
+func (s *chromaStore) collectionURL() string {
+	return s.baseURL + "/api/v1/collections/" + s.collectionID
+}
+
+func (s *chromaStore) Upsert(ctx context.Context, vectors []Vector) error {
+	req := chromaAddRequest{
+		IDs:        make([]string, len(vectors)),
+		Embeddings: make([][]float32, len(vectors)),
+		Metadatas:  make([]map[string]interface{}, len(vectors)),
+	}
+	for i, v := range vectors {
+		req.IDs[i] = v.ID
+		req.Embeddings[i] = v.Values
+		req.Metadatas[i] = v.Metadata
+	}
+	return doJSON(ctx, s.httpClient, http.MethodPost, s.collectionURL()+"/upsert", nil, req, nil)
+}
+
+func (s *chromaStore) Query(ctx context.Context, vec []float32, topK int, filter map[string]interface{}) ([]Match, error) {
+	req := chromaQueryRequest{
+		QueryEmbeddings: [][]float32{vec},
+		NResults:        topK,
+		Where:           filter,
+	}
+	var resp chromaQueryResponse
+	if err := doJSON(ctx, s.httpClient, http.MethodPost, s.collectionURL()+"/query", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	if len(resp.IDs) == 0 {
+		return nil, nil
+	}
+	ids, distances, metadatas := resp.IDs[0], resp.Distances[0], resp.Metadatas[0]
+	matches := make([]Match, len(ids))
+	for i, id := range ids {
+		// Chroma returns a distance, not a similarity score; invert so
+		// Match.Score stays "higher is more relevant" across drivers.
+		matches[i] = Match{ID: id, Score: 1 - distances[i], Metadata: metadatas[i]}
+	}
+	return matches, nil
+}
+
+func (s *chromaStore) Delete(ctx context.Context, ids []string) error {
+	return doJSON(ctx, s.httpClient, http.MethodPost, s.collectionURL()+"/delete", nil, chromaDeleteRequest{IDs: ids}, nil)
+}
+
+func (s *chromaStore) CreateIndex(ctx context.Context, name string, dimension int) error {
+	return doJSON(ctx, s.httpClient, http.MethodPost, s.baseURL+"/api/v1/collections", nil, chromaCreateCollectionRequest{
+		Name:     name,
+		Metadata: map[string]interface{}{"dimension": dimension},
+	}, nil)
+}
+
+type chromaAddRequest struct {
+	IDs        []string                 `json:"ids"`
+	Embeddings [][]float32              `json:"embeddings"`
+	Metadatas  []map[string]interface{} `json:"metadatas"`
+}
+
+type chromaQueryRequest struct {
+	QueryEmbeddings [][]float32            `json:"query_embeddings"`
+	NResults        int                    `json:"n_results"`
+	Where           map[string]interface{} `json:"where,omitempty"`
+}
+
+type chromaQueryResponse struct {
+	IDs       [][]string                 `json:"ids"`
+	Distances [][]float32                `json:"distances"`
+	Metadatas [][]map[string]interface{} `json:"metadatas"`
+}
+
+type chromaDeleteRequest struct {
+	IDs []string `json:"ids"`
+}
+
+type chromaCreateCollectionRequest struct {
+	Name     string                 `json:"name"`
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+}
+
+type chromaGetCollectionResponse struct {
+	ID string `json:"id"`
+}
@@ -0,0 +1,100 @@
+package vector
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// pineconeStore drives a Pinecone index over its REST API.
+type pineconeStore struct {
+	apiKey     string
+	indexHost  string // e.g. "my-index-abc123.svc.us-east1-gcp.pinecone.io"
+	httpClient *http.Client
+}
+
+// NewPineconeStore builds a Store backed by a Pinecone index. indexHost is
+// the per-index hostname Pinecone assigns after CreateIndex (the
+// "environment" is baked into that hostname, not passed separately).
+func NewPineconeStore(apiKey, indexHost string, httpClient *http.Client) Store {
+	return &pineconeStore{apiKey: apiKey, indexHost: indexHost, httpClient: newHTTPClient(httpClient)}
+}
+
+func (s *pineconeStore) headers() map[string]string {
+	return map[string]string{"Api-Key": s.apiKey}
+}
+
+func (s *pineconeStore) Upsert(ctx context.Context, vectors []Vector) error {
+	req := pineconeUpsertRequest{Vectors: make([]pineconeVector, len(vectors))}
+	for i, v := range vectors {
+		req.Vectors[i] = pineconeVector{ID: v.ID, Values: v.Values, Metadata: v.Metadata}
+	}
+	return doJSON(ctx, s.httpClient, http.MethodPost, "https://"+s.indexHost+"/vectors/upsert", s.headers(), req, nil)
+}
+
+func (s *pineconeStore) Query(ctx context.Context, vec []float32, topK int, filter map[string]interface{}) ([]Match, error) {
+	req := pineconeQueryRequest{
+		Vector:          vec,
+		TopK:            topK,
+		Filter:          filter,
+		IncludeMetadata: true,
+	}
+	var resp pineconeQueryResponse
+	if err := doJSON(ctx, s.httpClient, http.MethodPost, "https://"+s.indexHost+"/query", s.headers(), req, &resp); err != nil {
+		return nil, err
+	}
+	matches := make([]Match, len(resp.Matches))
+	for i, m := range resp.Matches {
+		matches[i] = Match{ID: m.ID, Score: m.Score, Metadata: m.Metadata}
+	}
+	return matches, nil
+}
+
+func (s *pineconeStore) Delete(ctx context.Context, ids []string) error {
+	return doJSON(ctx, s.httpClient, http.MethodPost, "https://"+s.indexHost+"/vectors/delete", s.headers(), pineconeDeleteRequest{IDs: ids}, nil)
+}
+
+func (s *pineconeStore) CreateIndex(ctx context.Context, name string, dimension int) error {
+	// Index creation is a control-plane call against api.pinecone.io, not the
+	// per-index data-plane host used for Upsert/Query/Delete.
+	req := pineconeCreateIndexRequest{Name: name, Dimension: dimension, Metric: "cosine"}
+	if err := doJSON(ctx, s.httpClient, http.MethodPost, "https://api.pinecone.io/indexes", s.headers(), req, nil); err != nil {
+		return fmt.Errorf("vector: pinecone create index %q: %w", name, err)
+	}
+	return nil
+}
+
+type pineconeVector struct {
+	ID       string                 `json:"id"`
+	Values   []float32              `json:"values"`
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+}
+
+type pineconeUpsertRequest struct {
+	Vectors []pineconeVector `json:"vectors"`
+}
+
+type pineconeQueryRequest struct {
+	Vector          []float32              `json:"vector"`
+	TopK            int                    `json:"topK"`
+	Filter          map[string]interface{} `json:"filter,omitempty"`
+	IncludeMetadata bool                   `json:"includeMetadata"`
+}
+
+type pineconeQueryResponse struct {
+	Matches []struct {
+		ID       string                 `json:"id"`
+		Score    float32                `json:"score"`
+		Metadata map[string]interface{} `json:"metadata"`
+	} `json:"matches"`
+}
+
+type pineconeDeleteRequest struct {
+	IDs []string `json:"ids"`
+}
+
+type pineconeCreateIndexRequest struct {
+	Name      string `json:"name"`
+	Dimension int    `json:"dimension"`
+	Metric    string `json:"metric"`
+}
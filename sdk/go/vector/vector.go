@@ -0,0 +1,84 @@
+// Package vector defines a provider-agnostic vector store interface, with
+// driver implementations for Pinecone, Weaviate, and Chroma. It has no
+// dependency on the parent aisdk package so it can be embedded or consumed
+// standalone.
+package vector
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Vector is a single embedding plus its opaque metadata, identified by ID.
+type Vector struct {
+	ID       string
+	Values   []float32
+	Metadata map[string]interface{}
+}
+
+// Match is a single scored result returned by Store.Query.
+type Match struct {
+	ID       string
+	Score    float32
+	Metadata map[string]interface{}
+}
+
+// Store is the provider-agnostic interface every vector database driver
+// implements.
+type Store interface {
+	Upsert(ctx context.Context, vectors []Vector) error
+	Query(ctx context.Context, vec []float32, topK int, filter map[string]interface{}) ([]Match, error)
+	Delete(ctx context.Context, ids []string) error
+	CreateIndex(ctx context.Context, name string, dimension int) error
+}
+
+// Embedder turns text into vector embeddings.
+type Embedder interface {
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+func newHTTPClient(c *http.Client) *http.Client {
+	if c != nil {
+		return c
+	}
+	return http.DefaultClient
+}
+
+func doJSON(ctx context.Context, client *http.Client, method, url string, headers map[string]string, body interface{}, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("vector: encoding request: %w", err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return fmt.Errorf("vector: building request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("vector: %s %s: %w", method, url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		msg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("vector: %s %s returned %s: %s", method, url, resp.Status, strings.TrimSpace(string(msg)))
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
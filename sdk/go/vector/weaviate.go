@@ -0,0 +1,184 @@
+package vector
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// weaviateStore drives a Weaviate class via its REST/GraphQL API.
+type weaviateStore struct {
+	baseURL    string
+	apiKey     string
+	className  string
+	httpClient *http.Client
+}
+
+// NewWeaviateStore builds a Store backed by a single Weaviate class
+// (analogous to a Pinecone/Chroma "index"/"collection").
+func NewWeaviateStore(baseURL, apiKey, className string, httpClient *http.Client) Store {
+	return &weaviateStore{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		apiKey:     apiKey,
+		className:  className,
+		httpClient: newHTTPClient(httpClient),
+	}
+}
+
+func (s *weaviateStore) headers() map[string]string {
+	h := map[string]string{}
+	if s.apiKey != "" {
+		h["Authorization"] = "Bearer " + s.apiKey
+	}
+	return h
+}
+
+func (s *weaviateStore) Upsert(ctx context.Context, vectors []Vector) error {
+	objects := make([]weaviateObject, len(vectors))
+	for i, v := range vectors {
+		objects[i] = weaviateObject{
+			ID:         v.ID,
+			Class:      s.className,
+			Vector:     v.Values,
+			Properties: v.Metadata,
+		}
+	}
+	return doJSON(ctx, s.httpClient, http.MethodPost, s.baseURL+"/v1/batch/objects", s.headers(), weaviateBatchRequest{Objects: objects}, nil)
+}
+
+func (s *weaviateStore) Query(ctx context.Context, vec []float32, topK int, filter map[string]interface{}) ([]Match, error) {
+	query := weaviateGraphQLQuery(s.className, vec, topK, filter)
+	var resp weaviateGraphQLResponse
+	if err := doJSON(ctx, s.httpClient, http.MethodPost, s.baseURL+"/v1/graphql", s.headers(), map[string]string{"query": query}, &resp); err != nil {
+		return nil, err
+	}
+	objs := resp.Data.Get["Get"][s.className]
+	matches := make([]Match, len(objs))
+	for i, o := range objs {
+		additional, _ := o["_additional"].(map[string]interface{})
+		id, _ := additional["id"].(string)
+		certainty, _ := additional["certainty"].(float64)
+		metadata := make(map[string]interface{}, len(o))
+		for k, v := range o {
+			if k != "_additional" {
+				metadata[k] = v
+			}
+		}
+		matches[i] = Match{ID: id, Score: float32(certainty), Metadata: metadata}
+	}
+	return matches, nil
+}
+
+func (s *weaviateStore) Delete(ctx context.Context, ids []string) error {
+	for _, id := range ids {
+		if err := doJSON(ctx, s.httpClient, http.MethodDelete, s.baseURL+"/v1/objects/"+s.className+"/"+id, s.headers(), nil, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *weaviateStore) CreateIndex(ctx context.Context, name string, dimension int) error {
+	return doJSON(ctx, s.httpClient, http.MethodPost, s.baseURL+"/v1/schema", s.headers(), weaviateClassSchema{
+		Class:      name,
+		Vectorizer: "none",
+	}, nil)
+}
+
+type weaviateObject struct {
+	ID         string                 `json:"id"`
+	Class      string                 `json:"class"`
+	Vector     []float32              `json:"vector"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+}
+
+type weaviateBatchRequest struct {
+	Objects []weaviateObject `json:"objects"`
+}
+
+type weaviateClassSchema struct {
+	Class      string `json:"class"`
+	Vectorizer string `json:"vectorizer"`
+}
+
+type weaviateGraphQLResponse struct {
+	Data struct {
+		Get map[string]map[string][]map[string]interface{} `json:"Get"`
+	} `json:"data"`
+}
+
+// weaviateGraphQLQuery builds a nearVector GraphQL query string. Weaviate's
+// GraphQL API requires property names to be listed explicitly (it has no
+// "select all properties" form), so this requests the "text" property the
+// RAGPipeline writes chunk content under. filter is translated into a
+// `where` argument by weaviateWhereClause.
+func weaviateGraphQLQuery(className string, vec []float32, topK int, filter map[string]interface{}) string {
+	var sb strings.Builder
+	sb.WriteString("{ Get { ")
+	sb.WriteString(className)
+	sb.WriteString("(nearVector: {vector: [")
+	for i, f := range vec {
+		if i > 0 {
+			sb.WriteString(",")
+		}
+		sb.WriteString(strconv.FormatFloat(float64(f), 'f', -1, 32))
+	}
+	sb.WriteString("]}, ")
+	sb.WriteString(weaviateWhereClause(filter))
+	sb.WriteString("limit: ")
+	sb.WriteString(strconv.Itoa(topK))
+	sb.WriteString(") { text _additional { id certainty } } } }")
+	return sb.String()
+}
+
+// weaviateWhereClause translates filter into a Weaviate GraphQL `where`
+// argument (plus trailing ", "), treating every entry as an exact-match
+// predicate on that metadata property and ANDing them together when there
+// is more than one. Keys are assumed to already match the property names
+// Upsert wrote (e.g. RAGPipeline's "doc_id"). Returns "" when filter is
+// empty, so callers can splice it straight into the query's argument list.
+func weaviateWhereClause(filter map[string]interface{}) string {
+	if len(filter) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(filter))
+	for k := range filter {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	clauses := make([]string, len(keys))
+	for i, k := range keys {
+		clauses[i] = fmt.Sprintf(`{path: [%q], operator: Equal, %s}`, k, weaviateValueArg(filter[k]))
+	}
+	if len(clauses) == 1 {
+		return "where: " + clauses[0] + ", "
+	}
+	return fmt.Sprintf("where: {operator: And, operands: [%s]}, ", strings.Join(clauses, ", "))
+}
+
+// weaviateValueArg returns the GraphQL "value<Type>: ..." argument matching
+// v's Go type, falling back to valueText via fmt.Sprint for anything else.
+func weaviateValueArg(v interface{}) string {
+	switch val := v.(type) {
+	case string:
+		return fmt.Sprintf("valueText: %q", val)
+	case bool:
+		return fmt.Sprintf("valueBoolean: %t", val)
+	case int:
+		return fmt.Sprintf("valueInt: %d", val)
+	case int64:
+		return fmt.Sprintf("valueInt: %d", val)
+	case float64:
+		if val == math.Trunc(val) {
+			return fmt.Sprintf("valueInt: %d", int64(val))
+		}
+		return fmt.Sprintf("valueNumber: %v", val)
+	default:
+		return fmt.Sprintf("valueText: %q", fmt.Sprint(val))
+	}
+}
@@ -0,0 +1,29 @@
+package vector
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWeaviateGraphQLQueryOmitsWhereWithoutFilter(t *testing.T) {
+	q := weaviateGraphQLQuery("Docs", []float32{0.1, 0.2}, 5, nil)
+	if strings.Contains(q, "where:") {
+		t.Fatalf("query contains a where clause with a nil filter: %s", q)
+	}
+}
+
+func TestWeaviateGraphQLQueryTranslatesSingleFilter(t *testing.T) {
+	q := weaviateGraphQLQuery("Docs", []float32{0.1}, 5, map[string]interface{}{"doc_id": "abc"})
+	want := `where: {path: ["doc_id"], operator: Equal, valueText: "abc"}, limit: 5`
+	if !strings.Contains(q, want) {
+		t.Fatalf("query = %q, want it to contain %q", q, want)
+	}
+}
+
+func TestWeaviateGraphQLQueryAndsMultipleFilters(t *testing.T) {
+	q := weaviateGraphQLQuery("Docs", []float32{0.1}, 5, map[string]interface{}{"doc_id": "abc", "chunk": 2})
+	want := `where: {operator: And, operands: [{path: ["chunk"], operator: Equal, valueInt: 2}, {path: ["doc_id"], operator: Equal, valueText: "abc"}]}, limit: 5`
+	if !strings.Contains(q, want) {
+		t.Fatalf("query = %q, want it to contain %q", q, want)
+	}
+}
@@ -0,0 +1,421 @@
+package aisdk
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/programs/system"
+	solanarpc "github.com/gagliardetto/solana-go/rpc"
+)
+
+// LogEvent is a chain event surfaced by Web3Client.SubscribeLogs, shaped to
+// fit both EVM logs and (loosely) Solana program log notifications.
+type LogEvent struct {
+	Address     string
+	Topics      []string
+	Data        []byte
+	TxHash      string
+	BlockNumber uint64
+}
+
+// TxReceipt is the outcome of a submitted transaction, as reported by
+// Web3Client.WatchTx.
+type TxReceipt struct {
+	TxHash      string
+	BlockNumber uint64
+	Success     bool
+}
+
+// Web3Client is the provider-agnostic interface every chain backend
+// implements, covering the operations Web3Config names but does not expose:
+// sending value/calldata, read-only contract calls, log subscriptions,
+// balance lookups, and message signing.
+type Web3Client interface {
+	SendTx(ctx context.Context, to string, value *big.Int, data []byte) (txHash string, err error)
+	CallContract(ctx context.Context, to string, data []byte) ([]byte, error)
+	SubscribeLogs(ctx context.Context, address string, topics []string) (<-chan LogEvent, error)
+	GetBalance(ctx context.Context, address string) (*big.Int, error)
+	SignMessage(msg []byte) ([]byte, error)
+	// WatchTx polls for txHash's receipt with exponential backoff until it
+	// is mined or ctx is done.
+	WatchTx(ctx context.Context, txHash string) (TxReceipt, error)
+}
+
+// chainInfo is a chain registry entry: the numeric chain ID EVM signing
+// needs, plus a conservative default gas policy for chains/networks that
+// don't expose reliable fee estimation.
+type chainInfo struct {
+	ChainID         int64 // 0 for non-EVM chains (e.g. Solana)
+	DefaultGasLimit uint64
+}
+
+// chainRegistry maps Web3Config.Chain + "/" + Web3Config.Network to its
+// chain metadata. See NewWeb3Config for the supported Chain/Network values;
+// additional chains are added here as the SDK gains support for them.
+var chainRegistry = map[string]chainInfo{
+	"ethereum/mainnet": {ChainID: 1, DefaultGasLimit: 21000},
+	"ethereum/testnet": {ChainID: 11155111, DefaultGasLimit: 21000}, // Sepolia
+	"polygon/mainnet":  {ChainID: 137, DefaultGasLimit: 21000},
+	"polygon/testnet":  {ChainID: 80002, DefaultGasLimit: 21000}, // Amoy
+	"solana/mainnet":   {DefaultGasLimit: 0},
+	"solana/testnet":   {DefaultGasLimit: 0},
+	"solana/devnet":    {DefaultGasLimit: 0},
+}
+
+func lookupChain(chain, network string) (chainInfo, error) {
+	info, ok := chainRegistry[chain+"/"+network]
+	if !ok {
+		return chainInfo{}, fmt.Errorf("aisdk: unknown chain/network combination %q/%q", chain, network)
+	}
+	return info, nil
+}
+
+// NewWeb3Client builds the concrete Web3Client for cfg.Chain ("ethereum",
+// "polygon", or any other EVM chain registered in chainRegistry use the EVM
+// driver; "solana" uses the Solana driver).
+func NewWeb3Client(cfg *Web3Config) (Web3Client, error) {
+	if cfg == nil {
+		return nil, errors.New("aisdk: nil Web3Config")
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	info, err := lookupChain(cfg.Chain, cfg.Network)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Chain == "solana" {
+		return newSolanaClient(cfg)
+	}
+	return newEVMClient(cfg, info)
+}
+
+// nonceManager hands out sequential nonces for concurrent sends from the
+// same address without waiting on a round-trip per send. It is seeded
+// lazily from the chain's pending nonce the first time an address is used.
+type nonceManager struct {
+	mu    sync.Mutex
+	next  map[common.Address]uint64
+	fetch func(ctx context.Context, addr common.Address) (uint64, error)
+}
+
+func newNonceManager(fetch func(ctx context.Context, addr common.Address) (uint64, error)) *nonceManager {
+	return &nonceManager{next: make(map[common.Address]uint64), fetch: fetch}
+}
+
+func (n *nonceManager) Next(ctx context.Context, addr common.Address) (uint64, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if _, seeded := n.next[addr]; !seeded {
+		nonce, err := n.fetch(ctx, addr)
+		if err != nil {
+			return 0, fmt.Errorf("aisdk: seeding nonce for %s: %w", addr.Hex(), err)
+		}
+		n.next[addr] = nonce
+	}
+	nonce := n.next[addr]
+	n.next[addr] = nonce + 1
+	return nonce, nil
+}
+
+// Release returns a nonce that was handed out by Next but never made it
+// into a broadcast transaction (gas estimation, signing, or the send RPC
+// itself failed), so the caller isn't permanently stuck behind a gap that
+// Ethereum's strictly-sequential nonce ordering would otherwise stall on
+// every subsequent send until the process restarts and reseeds.
+//
+// It only rewinds when nonce is the most recently issued one for addr: if a
+// concurrent call has already taken a higher nonce, rewinding here would
+// hand the same nonce out twice, so the gap is left for the next seed
+// instead (still recoverable, just not immediately).
+func (n *nonceManager) Release(addr common.Address, nonce uint64) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if next, ok := n.next[addr]; ok && next == nonce+1 {
+		n.next[addr] = nonce
+	}
+}
+
+// evmClient implements Web3Client for Ethereum and other EVM chains via
+// go-ethereum's ethclient.
+type evmClient struct {
+	cfg        *Web3Config
+	chain      chainInfo
+	client     *ethclient.Client
+	privateKey *ecdsa.PrivateKey
+	address    common.Address
+	nonces     *nonceManager
+}
+
+func newEVMClient(cfg *Web3Config, chain chainInfo) (*evmClient, error) {
+	client, err := ethclient.Dial(cfg.RPCUrl)
+	if err != nil {
+		return nil, fmt.Errorf("aisdk: dialing %s: %w", cfg.RPCUrl, err)
+	}
+	privateKey, err := crypto.HexToECDSA(cfg.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("aisdk: parsing Web3Config.PrivateKey: %w", err)
+	}
+	c := &evmClient{
+		cfg:        cfg,
+		chain:      chain,
+		client:     client,
+		privateKey: privateKey,
+		address:    crypto.PubkeyToAddress(privateKey.PublicKey),
+	}
+	c.nonces = newNonceManager(func(ctx context.Context, addr common.Address) (uint64, error) {
+		return client.PendingNonceAt(ctx, addr)
+	})
+	return c, nil
+}
+
+func (c *evmClient) SendTx(ctx context.Context, to string, value *big.Int, data []byte) (string, error) {
+	nonce, err := c.nonces.Next(ctx, c.address)
+	if err != nil {
+		return "", err
+	}
+	// From here on, any failure must release nonce: it has already been
+	// reserved, and leaving it stranded would stall every subsequent send
+	// from this address behind the gap.
+	gasPrice, err := c.client.SuggestGasPrice(ctx)
+	if err != nil {
+		c.nonces.Release(c.address, nonce)
+		return "", fmt.Errorf("aisdk: suggesting gas price: %w", err)
+	}
+	toAddr := common.HexToAddress(to)
+	gasLimit, err := c.client.EstimateGas(ctx, ethereum.CallMsg{From: c.address, To: &toAddr, Value: value, Data: data})
+	if err != nil {
+		gasLimit = c.chain.DefaultGasLimit
+	}
+
+	tx := types.NewTx(&types.LegacyTx{
+		Nonce:    nonce,
+		To:       &toAddr,
+		Value:    value,
+		Gas:      gasLimit,
+		GasPrice: gasPrice,
+		Data:     data,
+	})
+	signer := types.NewEIP155Signer(big.NewInt(c.chain.ChainID))
+	signedTx, err := types.SignTx(tx, signer, c.privateKey)
+	if err != nil {
+		c.nonces.Release(c.address, nonce)
+		return "", fmt.Errorf("aisdk: signing tx: %w", err)
+	}
+	if err := c.client.SendTransaction(ctx, signedTx); err != nil {
+		c.nonces.Release(c.address, nonce)
+		return "", fmt.Errorf("aisdk: sending tx: %w", err)
+	}
+	return signedTx.Hash().Hex(), nil
+}
+
+func (c *evmClient) CallContract(ctx context.Context, to string, data []byte) ([]byte, error) {
+	toAddr := common.HexToAddress(to)
+	return c.client.CallContract(ctx, ethereum.CallMsg{To: &toAddr, Data: data}, nil)
+}
+
+func (c *evmClient) SubscribeLogs(ctx context.Context, address string, topics []string) (<-chan LogEvent, error) {
+	query := ethereum.FilterQuery{Addresses: []common.Address{common.HexToAddress(address)}}
+	for _, t := range topics {
+		query.Topics = append(query.Topics, []common.Hash{common.HexToHash(t)})
+	}
+	rawLogs := make(chan types.Log)
+	sub, err := c.client.SubscribeFilterLogs(ctx, query, rawLogs)
+	if err != nil {
+		return nil, fmt.Errorf("aisdk: subscribing to logs: %w", err)
+	}
+
+	out := make(chan LogEvent)
+	go func() {
+		defer close(out)
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case err := <-sub.Err():
+				if err != nil {
+					return
+				}
+			case l := <-rawLogs:
+				topics := make([]string, len(l.Topics))
+				for i, t := range l.Topics {
+					topics[i] = t.Hex()
+				}
+				select {
+				case out <- LogEvent{Address: l.Address.Hex(), Topics: topics, Data: l.Data, TxHash: l.TxHash.Hex(), BlockNumber: l.BlockNumber}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return out, nil
+}
+
+func (c *evmClient) GetBalance(ctx context.Context, address string) (*big.Int, error) {
+	return c.client.BalanceAt(ctx, common.HexToAddress(address), nil)
+}
+
+func (c *evmClient) SignMessage(msg []byte) ([]byte, error) {
+	hash := crypto.Keccak256Hash(append([]byte(fmt.Sprintf("\x19Ethereum Signed Message:\n%d", len(msg))), msg...))
+	return crypto.Sign(hash.Bytes(), c.privateKey)
+}
+
+func (c *evmClient) WatchTx(ctx context.Context, txHash string) (TxReceipt, error) {
+	hash := common.HexToHash(txHash)
+	return pollWithBackoff(ctx, func() (TxReceipt, bool, error) {
+		receipt, err := c.client.TransactionReceipt(ctx, hash)
+		if errors.Is(err, ethereum.NotFound) {
+			return TxReceipt{}, false, nil
+		}
+		if err != nil {
+			return TxReceipt{}, false, err
+		}
+		return TxReceipt{
+			TxHash:      txHash,
+			BlockNumber: receipt.BlockNumber.Uint64(),
+			Success:     receipt.Status == types.ReceiptStatusSuccessful,
+		}, true, nil
+	})
+}
+
+// solanaClient implements Web3Client for Solana via solana-go.
+type solanaClient struct {
+	rpc        *solanarpc.Client
+	privateKey solana.PrivateKey
+}
+
+func newSolanaClient(cfg *Web3Config) (*solanaClient, error) {
+	privateKey, err := solana.PrivateKeyFromBase58(cfg.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("aisdk: parsing Web3Config.PrivateKey as a Solana keypair: %w", err)
+	}
+	return &solanaClient{rpc: solanarpc.New(cfg.RPCUrl), privateKey: privateKey}, nil
+}
+
+// SendTx sends a native SOL transfer of value lamports to to. Solana has no
+// generic "value + calldata" transaction shape, so data is ignored; calling
+// an arbitrary program requires building its instruction directly against
+// the rpc client this driver wraps.
+func (c *solanaClient) SendTx(ctx context.Context, to string, value *big.Int, data []byte) (string, error) {
+	recipient, err := solana.PublicKeyFromBase58(to)
+	if err != nil {
+		return "", fmt.Errorf("aisdk: parsing recipient address: %w", err)
+	}
+	latest, err := c.rpc.GetLatestBlockhash(ctx, solanarpc.CommitmentFinalized)
+	if err != nil {
+		return "", fmt.Errorf("aisdk: fetching latest blockhash: %w", err)
+	}
+	tx, err := solana.NewTransaction(
+		[]solana.Instruction{
+			system.NewTransferInstruction(value.Uint64(), c.privateKey.PublicKey(), recipient).Build(),
+		},
+		latest.Value.Blockhash,
+		solana.TransactionPayer(c.privateKey.PublicKey()),
+	)
+	if err != nil {
+		return "", fmt.Errorf("aisdk: building transaction: %w", err)
+	}
+	if _, err := tx.Sign(func(key solana.PublicKey) *solana.PrivateKey {
+		if key.Equals(c.privateKey.PublicKey()) {
+			return &c.privateKey
+		}
+		return nil
+	}); err != nil {
+		return "", fmt.Errorf("aisdk: signing transaction: %w", err)
+	}
+	sig, err := c.rpc.SendTransaction(ctx, tx)
+	if err != nil {
+		return "", fmt.Errorf("aisdk: sending transaction: %w", err)
+	}
+	return sig.String(), nil
+}
+
+// CallContract has no Solana equivalent (there is no "static call" RPC; the
+// closest is simulateTransaction against a full instruction, which needs
+// far more context than (programID, calldata)).
+func (c *solanaClient) CallContract(ctx context.Context, to string, data []byte) ([]byte, error) {
+	return nil, errors.New("aisdk: CallContract is not supported for Solana; use the rpc client's SimulateTransaction with a full instruction")
+}
+
+// SubscribeLogs is not implemented for Solana yet: log streaming requires a
+// websocket subscription (solana-go/rpc/ws), a separate connection type
+// from the JSON-RPC client this driver wraps.
+func (c *solanaClient) SubscribeLogs(ctx context.Context, address string, topics []string) (<-chan LogEvent, error) {
+	return nil, errors.New("aisdk: SubscribeLogs is not yet implemented for Solana")
+}
+
+func (c *solanaClient) GetBalance(ctx context.Context, address string) (*big.Int, error) {
+	pubkey, err := solana.PublicKeyFromBase58(address)
+	if err != nil {
+		return nil, fmt.Errorf("aisdk: parsing address: %w", err)
+	}
+	result, err := c.rpc.GetBalance(ctx, pubkey, solanarpc.CommitmentFinalized)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetUint64(result.Value), nil
+}
+
+func (c *solanaClient) SignMessage(msg []byte) ([]byte, error) {
+	sig, err := c.privateKey.Sign(msg)
+	if err != nil {
+		return nil, err
+	}
+	return sig[:], nil
+}
+
+func (c *solanaClient) WatchTx(ctx context.Context, txHash string) (TxReceipt, error) {
+	sig, err := solana.SignatureFromBase58(txHash)
+	if err != nil {
+		return TxReceipt{}, fmt.Errorf("aisdk: parsing signature: %w", err)
+	}
+	return pollWithBackoff(ctx, func() (TxReceipt, bool, error) {
+		statuses, err := c.rpc.GetSignatureStatuses(ctx, false, sig)
+		if err != nil {
+			return TxReceipt{}, false, err
+		}
+		if len(statuses.Value) == 0 || statuses.Value[0] == nil {
+			return TxReceipt{}, false, nil
+		}
+		status := statuses.Value[0]
+		return TxReceipt{TxHash: txHash, BlockNumber: status.Slot, Success: status.Err == nil}, true, nil
+	})
+}
+
+// pollWithBackoff calls check until it reports ready, applying exponential
+// backoff between attempts, or returns ctx.Err() if ctx is done first.
+func pollWithBackoff(ctx context.Context, check func() (TxReceipt, bool, error)) (TxReceipt, error) {
+	delay := 500 * time.Millisecond
+	const maxDelay = 10 * time.Second
+	for {
+		receipt, ready, err := check()
+		if err != nil {
+			return TxReceipt{}, err
+		}
+		if ready {
+			return receipt, nil
+		}
+		select {
+		case <-time.After(delay):
+			delay *= 2
+			if delay > maxDelay {
+				delay = maxDelay
+			}
+		case <-ctx.Done():
+			return TxReceipt{}, ctx.Err()
+		}
+	}
+}
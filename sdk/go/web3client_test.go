@@ -0,0 +1,119 @@
+package aisdk
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestNonceManagerSequential(t *testing.T) {
+	addr := common.HexToAddress("0x1")
+	n := newNonceManager(func(ctx context.Context, addr common.Address) (uint64, error) {
+		return 5, nil
+	})
+
+	first, err := n.Next(context.Background(), addr)
+	if err != nil {
+		t.Fatalf("Next: %v", err)
+	}
+	if first != 5 {
+		t.Fatalf("first nonce = %d, want 5 (seeded value)", first)
+	}
+	second, err := n.Next(context.Background(), addr)
+	if err != nil {
+		t.Fatalf("Next: %v", err)
+	}
+	if second != 6 {
+		t.Fatalf("second nonce = %d, want 6", second)
+	}
+}
+
+func TestNonceManagerReleaseRewindsMostRecentNonce(t *testing.T) {
+	addr := common.HexToAddress("0x1")
+	n := newNonceManager(func(ctx context.Context, addr common.Address) (uint64, error) {
+		return 0, nil
+	})
+
+	nonce, err := n.Next(context.Background(), addr)
+	if err != nil {
+		t.Fatalf("Next: %v", err)
+	}
+	n.Release(addr, nonce)
+
+	// A failed send must not strand a gap: the next caller should get the
+	// same nonce back, not nonce+1.
+	retried, err := n.Next(context.Background(), addr)
+	if err != nil {
+		t.Fatalf("Next: %v", err)
+	}
+	if retried != nonce {
+		t.Fatalf("nonce after Release = %d, want the released nonce %d back", retried, nonce)
+	}
+}
+
+func TestNonceManagerReleaseDoesNotRewindPastConcurrentIssue(t *testing.T) {
+	addr := common.HexToAddress("0x1")
+	n := newNonceManager(func(ctx context.Context, addr common.Address) (uint64, error) {
+		return 0, nil
+	})
+
+	first, err := n.Next(context.Background(), addr)
+	if err != nil {
+		t.Fatalf("Next: %v", err)
+	}
+	second, err := n.Next(context.Background(), addr)
+	if err != nil {
+		t.Fatalf("Next: %v", err)
+	}
+
+	// first's send failed, but second has already been handed out for a
+	// concurrent send; releasing first must not rewind past second, or the
+	// next caller would be handed second's nonce again.
+	n.Release(addr, first)
+
+	third, err := n.Next(context.Background(), addr)
+	if err != nil {
+		t.Fatalf("Next: %v", err)
+	}
+	if third == second {
+		t.Fatalf("Release of a stale nonce handed out nonce %d a second time", third)
+	}
+}
+
+func TestNonceManagerConcurrentNextNeverRepeats(t *testing.T) {
+	addr := common.HexToAddress("0x1")
+	n := newNonceManager(func(ctx context.Context, addr common.Address) (uint64, error) {
+		return 0, nil
+	})
+
+	const goroutines = 50
+	seen := make(chan uint64, goroutines)
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+	for i := 0; i < goroutines; i++ {
+		go func() {
+			defer wg.Done()
+			nonce, err := n.Next(context.Background(), addr)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			seen <- nonce
+		}()
+	}
+	wg.Wait()
+	close(seen)
+
+	unique := make(map[uint64]bool)
+	for nonce := range seen {
+		if unique[nonce] {
+			t.Fatalf("nonce %d handed out more than once", nonce)
+		}
+		unique[nonce] = true
+	}
+	if len(unique) != goroutines {
+		t.Fatalf("got %d unique nonces, want %d", len(unique), goroutines)
+	}
+}